@@ -3,19 +3,32 @@ package admin
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"r0Website-server/global"
 	"r0Website-server/models/po"
 	"r0Website-server/models/vo"
 	"r0Website-server/service"
 	"r0Website-server/utils/msg"
+	"strconv"
+	"time"
 )
 
 type ArticleController struct {
 	ArticleService *service.ArticleService `R0Ioc:"true"`
 }
 
-// ArticleList 文章列表
+// ArticleList 文章列表 可按状态查看草稿与定时发布的文章
 func (articleCon *ArticleController) ArticleList(c *gin.Context) {
-
+	var params vo.AdminArticleListVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.AdminArticleList(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
 
 // ArticleFileWay 增加文章通过上传文件
@@ -44,6 +57,79 @@ func (articleCon *ArticleController) ArticleFileWay(c *gin.Context) {
 	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
 
+// ArticleImport 批量导入zip压缩包中的md文件
+func (articleCon *ArticleController) ArticleImport(c *gin.Context) {
+	var params vo.AdminArticleImportVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	if params.Author == "" {
+		if curUser, exists := c.Get("userInfo"); exists {
+			if val, ok := curUser.(po.User); ok {
+				params.Author = val.Username
+			}
+		}
+	}
+	ans, err := articleCon.ArticleService.ArticleImport(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleExport 导出全站文章为zip，边查询边下发
+func (articleCon *ArticleController) ArticleExport(c *gin.Context) {
+	var params vo.AdminArticleExportVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	fileName := "r0website-export-" + time.Now().Format("20060102-150405") + ".zip"
+	c.Header("Content-Type", "application/zip")
+	c.Header("Content-Disposition", `attachment; filename="`+fileName+`"`)
+	if err := articleCon.ArticleService.ArticleExport(params, c.Writer); err != nil {
+		global.Logger.Error(err)
+		// 已经开始下发时无法再返回错误，只能中断
+		if !c.Writer.Written() {
+			c.Writer.Header().Del("Content-Disposition")
+			c.Writer.Header().Del("Content-Type")
+			c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		}
+	}
+}
+
+// ArticleStats 日期范围内的阅读统计，带id时为单篇文章的统计
+func (articleCon *ArticleController) ArticleStats(c *gin.Context) {
+	var params vo.AdminArticleStatsVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleStats(params, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleTop 最近一段时间阅读数最多的文章
+func (articleCon *ArticleController) ArticleTop(c *gin.Context) {
+	var params vo.AdminArticleTopVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleTop(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
 // ArticleFormWay 增加文章通过编辑方式 提交一个**表单**
 func (articleCon *ArticleController) ArticleFormWay(c *gin.Context) {
 	articleID := c.Param("id")
@@ -70,7 +156,7 @@ func (articleCon *ArticleController) ArticleFormWay(c *gin.Context) {
 	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
 
-// ArticleDelete 文章的删除（支持批量删除）
+// ArticleDelete 文章的删除，移入回收站
 func (articleCon *ArticleController) ArticleDelete(c *gin.Context) {
 	articleId := c.Param("id")
 	count, err := articleCon.ArticleService.DeleteArticle(articleId)
@@ -82,9 +168,136 @@ func (articleCon *ArticleController) ArticleDelete(c *gin.Context) {
 	}
 }
 
-// ArticleOverhead 文章的顶置
+// ArticleTrash 回收站中的文章
+func (articleCon *ArticleController) ArticleTrash(c *gin.Context) {
+	var params vo.BaseParams
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleTrash(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleRestore 将文章移出回收站
+func (articleCon *ArticleController) ArticleRestore(c *gin.Context) {
+	articleId := c.Param("id")
+	count, err := articleCon.ArticleService.RestoreArticle(articleId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(&vo.ArticleDeleteRes{Count: count}))
+}
+
+// ArticlePurge 彻底删除回收站中的一篇文章
+func (articleCon *ArticleController) ArticlePurge(c *gin.Context) {
+	articleId := c.Param("id")
+	count, err := articleCon.ArticleService.PurgeArticle(articleId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(&vo.ArticleDeleteRes{Count: count}))
+}
+
+// ArticlePurgeTrash 彻底删除回收站中超过保留期的文章
+func (articleCon *ArticleController) ArticlePurgeTrash(c *gin.Context) {
+	var params vo.AdminArticlePurgeVo
+	if err := c.ShouldBind(&params); err != nil || (params.RetentionDays != nil && *params.RetentionDays < 0) {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	retention := service.TrashRetention()
+	if params.RetentionDays != nil {
+		retention = time.Duration(*params.RetentionDays) * 24 * time.Hour
+	}
+	count, err := articleCon.ArticleService.PurgeExpiredTrash(retention)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(&vo.ArticleDeleteRes{Count: count}))
+}
+
+// ArticleOverhead 文章的顶置 可指定置顶顺序与到期时间
 func (articleCon *ArticleController) ArticleOverhead(c *gin.Context) {
+	articleID := c.Param("id")
+	var params vo.AdminArticleOverheadVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleOverhead(params, articleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleSlugBackfill 为还没有slug的旧文章生成slug
+func (articleCon *ArticleController) ArticleSlugBackfill(c *gin.Context) {
+	ans, err := articleCon.ArticleService.BackfillSlugs()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleContentStatBackfill 重新统计所有文章的字数与阅读时长
+func (articleCon *ArticleController) ArticleContentStatBackfill(c *gin.Context) {
+	ans, err := articleCon.ArticleService.BackfillContentStats()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleTags 回收站以外文章的标签及其文章数
+func (articleCon *ArticleController) ArticleTags(c *gin.Context) {
+	ans, err := articleCon.ArticleService.AdminArticleTags()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleTagRename 重命名文章标签 新的标签已存在时等同于合并
+func (articleCon *ArticleController) ArticleTagRename(c *gin.Context) {
+	var params vo.AdminArticleTagRenameVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.RenameArticleTag(c.Param("tag"), params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
 
+// ArticleTagMerge 将若干文章标签合并为一个
+func (articleCon *ArticleController) ArticleTagMerge(c *gin.Context) {
+	var params vo.AdminArticleTagMergeVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.MergeArticleTags(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
 
 // ArticleContent 文章内容
@@ -92,12 +305,88 @@ func (articleCon *ArticleController) ArticleContent(c *gin.Context) {
 
 }
 
-// ArticleUpdate 文章的更新
+// ArticleUpdate 文章的更新 只修改提交了的字段
 func (articleCon *ArticleController) ArticleUpdate(c *gin.Context) {
-
+	articleID := c.Param("id")
+	var articleUpdate vo.AdminArticleUpdateVo
+	if err := c.ShouldBind(&articleUpdate); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleUpdate(articleUpdate, articleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
 
-// ArticleBackup 文章的备份
+// ArticleBackup 文章的备份 手动为当前内容做一次快照
 func (articleCon *ArticleController) ArticleBackup(c *gin.Context) {
+	articleID := c.Param("id")
+	ans, err := articleCon.ArticleService.ArticleBackup(articleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleRevisions 文章的历史版本列表
+func (articleCon *ArticleController) ArticleRevisions(c *gin.Context) {
+	articleID := c.Param("id")
+	ans, err := articleCon.ArticleService.ArticleRevisions(articleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleRevision 文章的某一个历史版本
+func (articleCon *ArticleController) ArticleRevision(c *gin.Context) {
+	articleID := c.Param("id")
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleRevision(articleID, version)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// ArticleRevisionDiff 比较文章的两个版本
+func (articleCon *ArticleController) ArticleRevisionDiff(c *gin.Context) {
+	articleID := c.Param("id")
+	var params vo.ArticleRevisionDiffParamsVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleRevisionDiff(articleID, params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
 
+// ArticleRevisionRestore 将文章恢复到某一个历史版本
+func (articleCon *ArticleController) ArticleRevisionRestore(c *gin.Context) {
+	articleID := c.Param("id")
+	version, err := strconv.ParseInt(c.Param("version"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := articleCon.ArticleService.ArticleRevisionRestore(articleID, version)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
 }
@@ -26,3 +26,74 @@ func (cc *CategoryController) ArchiveArticle(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
 }
+
+// Create 新建分类
+func (cc *CategoryController) Create(c *gin.Context) {
+	var input vo.AdminCategoryCreateVo
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	result, err := cc.CategoryService.Create(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// Update 修改分类 名称变化时重命名并同步修改文章中的分类
+func (cc *CategoryController) Update(c *gin.Context) {
+	var input vo.AdminCategoryUpdateVo
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	result, err := cc.CategoryService.Update(c.Param("name"), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// Merge 将若干分类合并为一个
+func (cc *CategoryController) Merge(c *gin.Context) {
+	var input vo.AdminCategoryMergeVo
+	if err := c.ShouldBind(&input); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	result, err := cc.CategoryService.Merge(input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// Delete 删除分类 可以将文章改为归入另一个分类
+func (cc *CategoryController) Delete(c *gin.Context) {
+	var input vo.AdminCategoryDeleteVo
+	// DELETE请求没有请求体，to只从查询参数中读取
+	if err := c.ShouldBindQuery(&input); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	result, err := cc.CategoryService.Delete(c.Param("name"), input)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// Repair 由文章重新统计分类的文章id与文章数
+func (cc *CategoryController) Repair(c *gin.Context) {
+	result, err := cc.CategoryService.Repair()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
@@ -0,0 +1,69 @@
+// Package admin
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 管理员下的评论审核api
+ * @File:  admin_comment_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/models/vo"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+)
+
+type CommentController struct {
+	CommentService *service.CommentService `R0Ioc:"true"`
+}
+
+// CommentList 评论列表 可按审核状态与文章过滤
+func (cc *CommentController) CommentList(c *gin.Context) {
+	var params vo.AdminCommentListVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := cc.CommentService.AdminCommentList(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// CommentApprove 评论通过审核
+func (cc *CommentController) CommentApprove(c *gin.Context) {
+	cc.responseResult(c, cc.CommentService.ApproveComment)
+}
+
+// CommentReject 拒绝评论
+func (cc *CommentController) CommentReject(c *gin.Context) {
+	cc.responseResult(c, cc.CommentService.RejectComment)
+}
+
+// CommentSpam 标记为垃圾评论
+func (cc *CommentController) CommentSpam(c *gin.Context) {
+	cc.responseResult(c, cc.CommentService.SpamComment)
+}
+
+// CommentDelete 删除评论 回复一并删除
+func (cc *CommentController) CommentDelete(c *gin.Context) {
+	cc.responseResult(c, cc.CommentService.DeleteComment)
+}
+
+// responseResult 以路径中的id执行审核操作并返回结果
+func (cc *CommentController) responseResult(
+	c *gin.Context, action func(id string) (*vo.AdminCommentResultVo, error),
+) {
+	ans, err := action(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
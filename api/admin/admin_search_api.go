@@ -0,0 +1,41 @@
+// Package admin
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 管理员下的文章全文索引api
+ * @File:  admin_search_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+)
+
+type SearchController struct {
+	ArticleService *service.ArticleService `R0Ioc:"true"`
+}
+
+// SearchIndexStatus 全文索引的状态
+func (sc *SearchController) SearchIndexStatus(c *gin.Context) {
+	ans, err := sc.ArticleService.SearchIndexStatus()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// SearchIndexRebuild 从数据库重新建立全文索引
+func (sc *SearchController) SearchIndexRebuild(c *gin.Context) {
+	ans, err := sc.ArticleService.RebuildSearchIndex()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
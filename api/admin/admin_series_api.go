@@ -0,0 +1,82 @@
+// Package admin
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 管理员下的文章系列api
+ * @File:  admin_series_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/models/vo"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+)
+
+type SeriesController struct {
+	SeriesService *service.SeriesService `R0Ioc:"true"`
+}
+
+// SeriesAdd 新建系列
+func (sc *SeriesController) SeriesAdd(c *gin.Context) {
+	var params vo.AdminSeriesAddVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := sc.SeriesService.CreateSeries(params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// SeriesList 所有系列
+func (sc *SeriesController) SeriesList(c *gin.Context) {
+	ans, err := sc.SeriesService.AllSeries()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// SeriesDetail 某一系列 带上文章的标题与状态
+func (sc *SeriesController) SeriesDetail(c *gin.Context) {
+	ans, err := sc.SeriesService.SeriesDetail(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// SeriesUpdate 修改系列 只修改提交了的字段
+func (sc *SeriesController) SeriesUpdate(c *gin.Context) {
+	var params vo.AdminSeriesUpdateVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := sc.SeriesService.UpdateSeries(params, c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// SeriesDelete 删除系列 文章不受影响
+func (sc *SeriesController) SeriesDelete(c *gin.Context) {
+	count, err := sc.SeriesService.DeleteSeries(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(&vo.ArticleDeleteRes{Count: count}))
+}
@@ -12,10 +12,13 @@ package base
 import (
 	"github.com/gin-gonic/gin"
 	"net/http"
+	"path"
 	"r0Website-server/global"
+	"r0Website-server/models/bo"
 	"r0Website-server/models/vo"
 	"r0Website-server/service"
 	"r0Website-server/utils/msg"
+	"strconv"
 )
 
 type ArticleController struct {
@@ -25,7 +28,19 @@ type ArticleController struct {
 // AddPraise 增加一次赞
 func (article *ArticleController) AddPraise(c *gin.Context) {
 	articleID := c.Param("id")
-	if res, err := article.ArticleService.AddPraise(articleID); err != nil {
+	if res, err := article.ArticleService.AddPraise(articleID, articleVisit(c)); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	} else {
+		c.JSON(http.StatusOK, msg.NewMsg().Success(res))
+	}
+}
+
+// RemovePraise 取消赞
+func (article *ArticleController) RemovePraise(c *gin.Context) {
+	articleID := c.Param("id")
+	if res, err := article.ArticleService.RemovePraise(articleID, articleVisit(c)); err != nil {
 		global.Logger.Error(err)
 		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
 		return
@@ -37,7 +52,7 @@ func (article *ArticleController) AddPraise(c *gin.Context) {
 // AddPV 增加一次pv
 func (article *ArticleController) AddPV(c *gin.Context) {
 	articleID := c.Param("id")
-	if res, err := article.ArticleService.AddPV(articleID); err != nil {
+	if res, err := article.ArticleService.AddPV(articleID, articleVisit(c)); err != nil {
 		global.Logger.Error(err)
 		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
 		return
@@ -46,6 +61,31 @@ func (article *ArticleController) AddPV(c *gin.Context) {
 	}
 }
 
+// articleVisit 访问的来源信息
+// 访客令牌由客户端生成并保存，与IP、UA一起用于去重，可以为空
+// 请求由前台页面发出，因此Referer即阅读文章的页面，访客真正的来源需要前台通过referrer参数传递
+func articleVisit(c *gin.Context) bo.ArticleVisit {
+	token := c.GetHeader("X-Visitor-Token")
+	if token == "" {
+		token = c.Query("visitor_token")
+	}
+	if len(token) > 128 {
+		token = token[:128]
+	}
+	visit := bo.ArticleVisit{
+		Ip:        c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Token:     token,
+		Referrer:  c.Query("referrer"),
+		Page:      c.Request.Referer(),
+		Origin:    requestOrigin(c),
+	}
+	if page := c.Query("path"); page != "" {
+		visit.Page = page
+	}
+	return visit
+}
+
 // ArticleSearch 模糊搜索文章内容，依赖分词冗杂，允许带空格
 // 如果带id那就是精准查找
 // ShouldBindJSON > ShouldBind
@@ -67,6 +107,41 @@ func (article *ArticleController) ArticleSearch(c *gin.Context) {
 	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
 }
 
+// ArticleBySlug 通过slug获取文章 旧的slug会被301重定向到当前的slug
+func (article *ArticleController) ArticleBySlug(c *gin.Context) {
+	var params vo.BaseArticleSearchVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	result, redirect, err := article.ArticleService.ArticleBySlug(c.Param("slug"), params)
+	if err != nil {
+		c.JSON(http.StatusNotFound, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	if redirect != "" {
+		location := path.Join(path.Dir(c.Request.URL.Path), redirect)
+		if c.Request.URL.RawQuery != "" {
+			location += "?" + c.Request.URL.RawQuery
+		}
+		c.Redirect(http.StatusMovedPermanently, location)
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// HighlightCSS 代码高亮的css 通过style选择配色
+func (article *ArticleController) HighlightCSS(c *gin.Context) {
+	css, err := article.ArticleService.HighlightCSS(c.Query("style"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.Header("Cache-Control", "public, max-age=86400")
+	c.Data(http.StatusOK, "text/css; charset=utf-8", []byte(css))
+}
+
 // ArticleInCategory 获取分类下的文章
 func (article *ArticleController) ArticleInCategory(c *gin.Context) {
 	var params vo.ArticleSearchByCategoryVo
@@ -85,3 +160,101 @@ func (article *ArticleController) ArticleInCategory(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
 }
+
+// ArticleTags 所有标签及其文章数
+func (article *ArticleController) ArticleTags(c *gin.Context) {
+	result, err := article.ArticleService.ArticleTags()
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// ArticleInTag 某一标签下的文章
+func (article *ArticleController) ArticleInTag(c *gin.Context) {
+	var params vo.ArticleSearchByTagVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	params.Tag = c.Param("tag")
+	result, err := article.ArticleService.ArticleInTag(params)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// ArticleArchive 文章按年、月归档
+func (article *ArticleController) ArticleArchive(c *gin.Context) {
+	result, err := article.ArticleService.ArticleArchive()
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// ArticleInMonth 某一月份创建的文章
+func (article *ArticleController) ArticleInMonth(c *gin.Context) {
+	var params vo.ArticleSearchByMonthVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	year, yearErr := strconv.Atoi(c.Param("year"))
+	month, monthErr := strconv.Atoi(c.Param("month"))
+	if yearErr != nil || monthErr != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	params.Year, params.Month = year, month
+	result, err := article.ArticleService.ArticleInMonth(params)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// ArticleHot 热门文章
+func (article *ArticleController) ArticleHot(c *gin.Context) {
+	var params vo.BaseArticleHotVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	result, err := article.ArticleService.ArticleHot(params)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
+
+// ArticleRelated 相关文章
+func (article *ArticleController) ArticleRelated(c *gin.Context) {
+	var params vo.BaseArticleRelatedVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	result, err := article.ArticleService.ArticleRelated(c.Param("id"), params)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
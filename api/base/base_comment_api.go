@@ -0,0 +1,54 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: base的文章评论api
+ * @File:  base_comment_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/models/vo"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+)
+
+type CommentController struct {
+	CommentService *service.CommentService `R0Ioc:"true"`
+}
+
+// CommentList 文章下通过审核的评论
+func (comment *CommentController) CommentList(c *gin.Context) {
+	articleID := c.Param("id")
+	var params vo.BaseParams
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := comment.CommentService.CommentList(articleID, params)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
+
+// AddComment 发表评论 审核之后才会展示
+func (comment *CommentController) AddComment(c *gin.Context) {
+	articleID := c.Param("id")
+	var params vo.BaseCommentAddVo
+	if err := c.ShouldBind(&params); err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := comment.CommentService.AddComment(params, articleID, c.ClientIP(), c.Request.UserAgent())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(ans))
+}
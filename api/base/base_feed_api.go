@@ -0,0 +1,124 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 订阅源api，支持按Last-Modified的条件请求
+ * @File:  base_feed_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/global"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+	"strings"
+)
+
+type FeedController struct {
+	FeedService *service.FeedService `R0Ioc:"true"`
+}
+
+// Rss RSS 2.0，路径中带分类名时为该分类的订阅
+func (feed *FeedController) Rss(c *gin.Context) {
+	feed.serveFeed(c, "application/rss+xml; charset=utf-8", func(source *service.FeedSource) ([]byte, error) {
+		return marshalFeedXml(feed.FeedService.RssFeed(source))
+	})
+}
+
+// Atom Atom 1.0
+func (feed *FeedController) Atom(c *gin.Context) {
+	feed.serveFeed(c, "application/atom+xml; charset=utf-8", func(source *service.FeedSource) ([]byte, error) {
+		return marshalFeedXml(feed.FeedService.AtomFeed(source))
+	})
+}
+
+// Json JSON Feed 1.1
+func (feed *FeedController) Json(c *gin.Context) {
+	feed.serveFeed(c, "application/feed+json; charset=utf-8", func(source *service.FeedSource) ([]byte, error) {
+		buffer := &bytes.Buffer{}
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(feed.FeedService.JsonFeed(source)); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	})
+}
+
+// serveFeed 查询文章并处理条件请求，文章集合与更新时间都没有变化时返回304
+// 文章被移入回收站时剩余文章的时间不会变化，If-Modified-Since无法可靠判断，只按ETag返回304
+func (feed *FeedController) serveFeed(
+	c *gin.Context, contentType string, render func(source *service.FeedSource) ([]byte, error),
+) {
+	category := c.Param("name")
+	source, err := feed.FeedService.FeedArticles(category, requestOrigin(c), requestUrl(c))
+	if err != nil {
+		global.Logger.Error(err)
+		status := http.StatusBadRequest
+		if category != "" {
+			status = http.StatusNotFound
+		}
+		c.JSON(status, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	if !source.LastModified.IsZero() {
+		c.Header("Last-Modified", source.LastModified.UTC().Format(http.TimeFormat))
+	}
+	c.Header("ETag", source.ETag)
+	if etagMatches(c.GetHeader("If-None-Match"), source.ETag) {
+		c.Status(http.StatusNotModified)
+		return
+	}
+	data, err := render(source)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusInternalServerError, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.Data(http.StatusOK, contentType, data)
+}
+
+// etagMatches If-None-Match中是否包含etag，忽略弱校验的前缀
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, val := range strings.Split(ifNoneMatch, ",") {
+		val = strings.TrimPrefix(strings.TrimSpace(val), "W/")
+		if val == etag || val == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// requestUrl 当前请求的完整地址
+func requestUrl(c *gin.Context) string {
+	return requestOrigin(c) + c.Request.URL.Path
+}
+
+// requestOrigin 当前请求的协议与主机，反向代理时以X-Forwarded-Proto为准
+func requestOrigin(c *gin.Context) string {
+	scheme := "http"
+	if c.Request.TLS != nil {
+		scheme = "https"
+	}
+	if proto := c.GetHeader("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// marshalFeedXml 带xml声明的序列化
+func marshalFeedXml(v interface{}) ([]byte, error) {
+	data, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,40 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站搜索api
+ * @File:  base_search_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/global"
+	"r0Website-server/models/vo"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+)
+
+type SearchController struct {
+	SearchService *service.SearchService `R0Ioc:"true"`
+}
+
+// Search 全站搜索
+func (sc *SearchController) Search(c *gin.Context) {
+	var params vo.BaseSearchVo
+	if err := c.ShouldBind(&params); err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("查询参数异常"))
+		return
+	}
+	result, err := sc.SearchService.Search(params)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.JSON(http.StatusOK, msg.NewMsg().Success(result))
+}
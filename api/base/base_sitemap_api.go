@@ -0,0 +1,66 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 站点地图与robots api
+ * @File:  base_sitemap_api
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"r0Website-server/global"
+	"r0Website-server/service"
+	"r0Website-server/utils/msg"
+	"strconv"
+	"strings"
+)
+
+type SitemapController struct {
+	SitemapService *service.SitemapService `R0Ioc:"true"`
+}
+
+// Sitemap 站点地图 地址过多时返回站点地图索引
+func (sitemap *SitemapController) Sitemap(c *gin.Context) {
+	ans, err := sitemap.SitemapService.Sitemap(requestOrigin(c))
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusInternalServerError, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	renderSitemap(c, ans)
+}
+
+// SitemapPage 站点地图索引中的某一个站点地图 路径形如 /sitemap/1.xml
+func (sitemap *SitemapController) SitemapPage(c *gin.Context) {
+	page, err := strconv.Atoi(strings.TrimSuffix(c.Param("page"), ".xml"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, msg.NewMsg().Failed("参数异常"))
+		return
+	}
+	ans, err := sitemap.SitemapService.SitemapPage(requestOrigin(c), page)
+	if err != nil {
+		c.JSON(http.StatusNotFound, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	renderSitemap(c, ans)
+}
+
+// Robots robots.txt
+func (sitemap *SitemapController) Robots(c *gin.Context) {
+	c.String(http.StatusOK, sitemap.SitemapService.Robots(requestOrigin(c)))
+}
+
+// renderSitemap 输出带xml声明的站点地图
+func renderSitemap(c *gin.Context, v interface{}) {
+	data, err := marshalFeedXml(v)
+	if err != nil {
+		global.Logger.Error(err)
+		c.JSON(http.StatusInternalServerError, msg.NewMsg().Failed(err.Error()))
+		return
+	}
+	c.Data(http.StatusOK, "application/xml; charset=utf-8", data)
+}
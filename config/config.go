@@ -16,11 +16,16 @@ type SystemConfig struct {
 	Mongo        Mongo        `yaml:"mongo"`
 	Author       Author       `yaml:"author"`
 	TencentCloud TencentCloud `yaml:"tencent_cloud"`
+	Article      Article      `yaml:"article"`
+	Site         Site         `yaml:"site"`
+	Search       Search       `yaml:"search"`
 }
 
 type System struct {
 	Port   string `yaml:"port"`   // 端口
 	Status string `yaml:"status"` // 状态
+	// 可信的反向代理的IP或CIDR，只有来自这些地址的X-Forwarded-For才会被采用，默认不信任任何代理
+	TrustedProxies []string `yaml:"trusted-proxies"`
 }
 
 type Logger struct {
@@ -52,3 +57,39 @@ type TencentCloud struct {
 	Region    string `yaml:"region"`
 	Bucket    string `yaml:"bucket"`
 }
+
+type Article struct {
+	TrashRetentionDays int `yaml:"trash-retention-days"` // 回收站保留天数，超过后彻底删除，默认30天
+	RelatedSize        int `yaml:"related-size"`         // 文章详情中相关文章的数量，默认5篇
+	// 同一访客在此时间内重复阅读同一篇文章只计一次，默认30分钟
+	ViewDedupMinutes int `yaml:"view-dedup-minutes"`
+	// 去重时长内同一IP对一篇文章最多计入的访客数，阅读与点赞分别计算，默认5
+	VisitorsPerIp int `yaml:"visitors-per-ip"`
+	// 阅读数与点赞数的增量写入数据库的间隔，默认10秒
+	CounterFlushSeconds int `yaml:"counter-flush-seconds"`
+	// 按年月归档使用的IANA时区名，如Asia/Shanghai，默认使用服务器的时区
+	ArchiveTimezone string `yaml:"archive-timezone"`
+	// 热门文章得分的时间衰减指数，越大旧文章下沉得越快，默认1.8
+	HotGravity float64 `yaml:"hot-gravity"`
+	// 热门文章重新计算的间隔，默认10分钟
+	HotRefreshMinutes int `yaml:"hot-refresh-minutes"`
+}
+
+type Site struct {
+	Title       string `yaml:"title"`        // 站点名称，用于订阅源
+	Url         string `yaml:"url"`          // 前台地址，用于生成文章链接，如 https://example.com
+	Description string `yaml:"description"`  // 站点描述
+	ArticlePath string `yaml:"article-path"` // 文章在前台的路径，{id}会被替换为文章id，默认 /article/{id}
+	// 文章分类在前台的路径，{name}会被替换为分类名，默认 /category/{name}
+	CategoryPath string `yaml:"category-path"`
+	// 图集在前台的路径，{id}会被替换为图集id，默认 /album/{id}
+	AlbumPath string `yaml:"album-path"`
+	// 图片分类在前台的路径，{id}会被替换为分类id，默认 /gallery/{id}
+	ImageCategoryPath string `yaml:"image-category-path"`
+	FeedSize          int64  `yaml:"feed-size"` // 订阅源中的文章数，默认20
+}
+
+type Search struct {
+	Backend   string `yaml:"backend"`    // 文章搜索的后端，mongo: Mongo的$text检索; index: 进程内的倒排索引，默认mongo
+	IndexPath string `yaml:"index-path"` // 倒排索引的持久化路径，默认 ./data/article_search.index
+}
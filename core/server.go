@@ -10,19 +10,49 @@
 package core
 
 import (
+	"context"
+	"net/http"
+	"os"
+	"os/signal"
 	"r0Website-server/global"
 	"r0Website-server/router"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout 收到退出信号之后等待处理中的请求完成的时长
+const shutdownTimeout = 30 * time.Second
+
 type server interface {
 	ListenAndServe() error
+	Shutdown(ctx context.Context) error
 }
 
+// RunWindowsServer 启动服务器，阻塞直到服务器退出或收到SIGINT、SIGTERM并完成关闭
 func RunWindowsServer() {
 	// 初始化路由
 	Router := router.Routers()
 	// 初始化服务
 	s := initServer(global.Config.System.Port, Router)
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
 	// 启动服务器
-	s.ListenAndServe().Error()
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.ListenAndServe()
+	}()
+	select {
+	case err := <-errs:
+		if err != nil && err != http.ErrServerClosed {
+			global.Logger.Error(err)
+		}
+	case sig := <-quit:
+		global.Logger.Infof("收到%v信号，正在关闭服务", sig)
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := s.Shutdown(ctx); err != nil {
+			global.Logger.Errorf("关闭服务失败: %v", err)
+		}
+	}
 }
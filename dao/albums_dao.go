@@ -6,8 +6,10 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"r0Website-server/global"
 	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
 	"time"
 )
 
@@ -154,6 +156,21 @@ func (ad *AlbumDao) ListAlbums() ([]*po.Album, error) {
 	return albums, err
 }
 
+// ListPublicAlbumsBrief 获取所有公开图集的id与更新时间，用于站点地图
+func (ad *AlbumDao) ListPublicAlbumsBrief() ([]*po.Album, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "updated_at": 1})
+	cursor, err := ad.Collection().Find(context.TODO(), bson.M{"visibility": "public"}, opts)
+	if err != nil {
+		global.Logger.Errorf("❌ 获取公开图集列表失败: %v", err)
+		return nil, err
+	}
+	var albums []*po.Album
+	if err = cursor.All(context.TODO(), &albums); err != nil {
+		global.Logger.Errorf("❌ 解析公开图集列表失败: %v", err)
+	}
+	return albums, err
+}
+
 // FindAlbumsByAuthor 根据作者查图集
 func (ad *AlbumDao) FindAlbumsByAuthor(author string) ([]*po.Album, error) {
 	cursor, err := ad.Collection().Find(context.TODO(), bson.M{"author": author})
@@ -197,6 +214,32 @@ func (ad *AlbumDao) SearchAlbumsByKeyword(keyword string) ([]*po.Album, error) {
 	return albums, err
 }
 
+// SearchPublicAlbums 关键字搜索公开的图集，按相关度倒序，同时返回命中的总数
+func (ad *AlbumDao) SearchPublicAlbums(keyword string, limit int64) ([]vo.ScoredAlbumVo, int64, error) {
+	filter := bson.M{"$text": bson.M{"$search": keyword}, "visibility": "public"}
+	score := bson.M{"$meta": "textScore"}
+	opts := options.Find().
+		SetProjection(bson.M{"score": score, "image_refs": 0}).
+		SetSort(bson.M{"score": score}).
+		SetLimit(limit)
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Errorf("❌ 搜索图集失败: %v", err)
+		return nil, 0, err
+	}
+	albums := []vo.ScoredAlbumVo{}
+	if err = cursor.All(context.TODO(), &albums); err != nil {
+		global.Logger.Errorf("❌ 解析搜索结果失败: %v", err)
+		return nil, 0, err
+	}
+	total, err := ad.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Errorf("❌ 统计图集失败: %v", err)
+		return nil, 0, err
+	}
+	return albums, total, nil
+}
+
 // UpdateImageLayoutInAlbum 更新图集中某张图片的布局信息（位置、大小、描述等）
 func (ad *AlbumDao) UpdateImageLayoutInAlbum(
 	albumID, imageID primitive.ObjectID, layout *po.AlbumPosition, caption, description string,
@@ -0,0 +1,128 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章按创建时间归档
+ * @File:  article_archive_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"os"
+	"r0Website-server/global"
+	"r0Website-server/models/vo"
+	"strings"
+	"time"
+)
+
+// articleArchiveGroup 聚合结果中的一个年月分组
+type articleArchiveGroup struct {
+	Id struct {
+		Year  int `bson:"year"`
+		Month int `bson:"month"`
+	} `bson:"_id"`
+	Count    int64                     `bson:"count"`
+	Articles []vo.ArticleArchiveItemVo `bson:"articles"`
+}
+
+// archiveTimezone 归档使用的时区及其IANA名称，名称传给聚合中的$year与$month
+// 固定的时差在夏令时前后会把月初月末的文章分错组，因此尽量使用时区名，都无法确定时才退回当前的时差
+func archiveTimezone() (*time.Location, string) {
+	if global.Config != nil && global.Config.Article.ArchiveTimezone != "" {
+		name := global.Config.Article.ArchiveTimezone
+		if location, err := time.LoadLocation(name); err == nil {
+			return location, name
+		}
+		global.Logger.Errorf("无法识别归档时区%s，使用服务器的时区", name)
+	}
+	// 通过TZ环境变量设置时区时time.Local带有时区名
+	if name := time.Local.String(); name != "" && name != "Local" {
+		return time.Local, name
+	}
+	// 否则从/etc/localtime指向的文件推断时区名
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if index := strings.Index(target, "zoneinfo/"); index >= 0 {
+			name := target[index+len("zoneinfo/"):]
+			if location, err := time.LoadLocation(name); err == nil {
+				return location, name
+			}
+		}
+	}
+	return time.Local, time.Now().Format("-07:00")
+}
+
+// ArticleArchive 对外可见的文章按创建时间的年、月分组，年月按archiveTimezone计算
+func (ad *ArticleDao) ArticleArchive() (*vo.ArticleArchiveVo, error) {
+	location, timezone := archiveTimezone()
+	pipeline := bson.A{
+		bson.M{"$match": publicVisibleFilter()},
+		bson.M{"$sort": bson.M{"create_time": -1}},
+		bson.M{"$group": bson.M{
+			"_id": bson.M{
+				"year":  bson.M{"$year": bson.M{"date": "$create_time", "timezone": timezone}},
+				"month": bson.M{"$month": bson.M{"date": "$create_time", "timezone": timezone}},
+			},
+			"count": bson.M{"$sum": 1},
+			"articles": bson.M{"$push": bson.M{
+				"_id":         "$_id",
+				"title":       "$title",
+				"slug":        "$slug",
+				"create_time": "$create_time",
+			}},
+		}},
+		bson.M{"$sort": bson.D{{Key: "_id.year", Value: -1}, {Key: "_id.month", Value: -1}}},
+	}
+	cursor, err := ad.Collection().Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	var groups []articleArchiveGroup
+	if err = cursor.All(context.TODO(), &groups); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	result := vo.ArticleArchiveVo{Years: []vo.ArticleArchiveYearVo{}}
+	for _, group := range groups {
+		for index, val := range group.Articles {
+			group.Articles[index].CreateTime = val.CreateTime.In(location)
+		}
+		// 分组已经按年月倒序，同一年份的月份是连续的
+		if len(result.Years) == 0 || result.Years[len(result.Years)-1].Year != group.Id.Year {
+			result.Years = append(result.Years, vo.ArticleArchiveYearVo{
+				Year: group.Id.Year, Months: []vo.ArticleArchiveMonthVo{},
+			})
+		}
+		year := &result.Years[len(result.Years)-1]
+		year.Months = append(year.Months, vo.ArticleArchiveMonthVo{
+			Month: group.Id.Month, Count: group.Count, Articles: group.Articles,
+		})
+		year.Count += group.Count
+		result.TotalCount += group.Count
+	}
+	return &result, nil
+}
+
+// ArticleInMonth 某一月份创建的对外可见的文章，未指定排序时按创建时间倒序
+func (ad *ArticleDao) ArticleInMonth(params vo.ArticleSearchByMonthVo) (*vo.BaseArticleSearchResultVo, error) {
+	location, _ := archiveTimezone()
+	start := time.Date(params.Year, time.Month(params.Month), 1, 0, 0, 0, 0, location)
+	filter := append(bson.D{{Key: "create_time", Value: bson.M{
+		"$gte": start, "$lt": start.AddDate(0, 1, 0),
+	}}}, publicVisibleFilter()...)
+	opts, err := ad.getArticleBaseSearchOption(vo.BaseArticleSearchVo{BaseParams: params.BaseParams}, "")
+	if err != nil {
+		return nil, err
+	}
+	if !params.UpdateTimeSort.SortFlag && !params.CreateTimeSort.SortFlag {
+		opts = opts.SetSort(bson.D{{Key: "create_time", Value: -1}})
+	}
+	if params.Lazy {
+		opts = opts.SetProjection(bson.M{"markdown": 0})
+	}
+	return ad.findArticlePage(filter, opts, params.BaseParams)
+}
@@ -0,0 +1,71 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章中分类字段的批量修改与统计，用于分类的重命名、合并、删除与修复
+ * @File:  article_category_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"r0Website-server/global"
+)
+
+// MergeArticleCategories 将文章中的from分类替换为to，已经带有to的文章直接移除from，避免出现重复的分类
+func (ad *ArticleDao) MergeArticleCategories(from []string, to string) (int64, error) {
+	return ad.mergeArticleValues("categories", from, to)
+}
+
+// ArticleIdsWithCategories 带有任意一个分类的文章id，包括回收站中的文章
+func (ad *ArticleDao) ArticleIdsWithCategories(names []string) ([]primitive.ObjectID, error) {
+	return ad.articleIdsWithValues("categories", names)
+}
+
+// RemoveArticleCategory 从所有文章中移除分类
+func (ad *ArticleDao) RemoveArticleCategory(name string) (int64, error) {
+	res, err := ad.Collection().UpdateMany(context.TODO(),
+		bson.D{{Key: "categories", Value: name}},
+		bson.M{"$pull": bson.M{"categories": name}},
+	)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// CategoryArticleIds 由文章统计每个分类下的文章id，包括回收站中的文章，与分类的倒排保持一致
+func (ad *ArticleDao) CategoryArticleIds() (map[string][]string, error) {
+	var groups []struct {
+		Name string               `bson:"_id"`
+		Ids  []primitive.ObjectID `bson:"ids"`
+	}
+	pipeline := bson.A{
+		bson.M{"$unwind": "$categories"},
+		bson.M{"$match": bson.M{"categories": bson.M{"$ne": ""}}},
+		bson.M{"$group": bson.M{"_id": "$categories", "ids": bson.M{"$addToSet": "$_id"}}},
+	}
+	cursor, err := ad.Collection().Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &groups); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	result := make(map[string][]string, len(groups))
+	for _, group := range groups {
+		ids := make([]string, len(group.Ids))
+		for index, id := range group.Ids {
+			ids[index] = id.Hex()
+		}
+		result[group.Name] = ids
+	}
+	return result, nil
+}
@@ -21,6 +21,7 @@ import (
 	"r0Website-server/models/po"
 	"r0Website-server/models/vo"
 	"r0Website-server/utils"
+	"time"
 )
 
 type ArticleDao struct {
@@ -35,36 +36,78 @@ func (ad *ArticleDao) Collection() *mongo.Collection {
 	return ad.Mdb.Collection(ad.CollectionName())
 }
 
-// AddPraise 增加一次赞赏
-func (ad *ArticleDao) AddPraise(id string) (*mongo.UpdateResult, error) {
+// IncArticleCounters 批量累加文章的阅读数与点赞数，计数器累积的增量一次写入
+// 出错时返回写入失败的增量，已经写入的不包含在内，避免重试时重复累加
+func (ad *ArticleDao) IncArticleCounters(
+	deltas map[primitive.ObjectID]*bo.ArticleCounterDelta,
+) (map[primitive.ObjectID]*bo.ArticleCounterDelta, error) {
+	models := make([]mongo.WriteModel, 0, len(deltas))
+	ids := make([]primitive.ObjectID, 0, len(deltas))
+	for id, delta := range deltas {
+		inc := bson.M{}
+		if delta.Reads != 0 {
+			inc["reads_number"] = delta.Reads
+		}
+		if delta.Praises != 0 {
+			inc["praise_number"] = delta.Praises
+		}
+		if len(inc) == 0 {
+			continue
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"_id": id}).
+			SetUpdate(bson.M{"$inc": inc}))
+		ids = append(ids, id)
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	if _, err := ad.Collection().BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false)); err != nil {
+		global.Logger.Error(err)
+		failed := map[primitive.ObjectID]*bo.ArticleCounterDelta{}
+		for _, index := range failedBulkWrites(err, len(models)) {
+			failed[ids[index]] = deltas[ids[index]]
+		}
+		return failed, err
+	}
+	return nil, nil
+}
+
+// VisibleArticleId 对外可见的文章的id，文章不存在或不可见时返回错误
+func (ad *ArticleDao) VisibleArticleId(id string) (primitive.ObjectID, error) {
 	bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id))
 	if err != nil {
-		return nil, err
+		return primitive.NilObjectID, err
 	}
-	filter := bson.D{{"_id", bsonId}}
-	update := bson.D{{"$inc", bson.D{{"praise_number", 1}}}}
-	if res, err := ad.Collection().UpdateOne(context.TODO(), filter, update); err != nil {
+	filter := append(bson.D{{Key: "_id", Value: bsonId}}, publicVisibleFilter()...)
+	count, err := ad.Collection().CountDocuments(context.TODO(), filter, options.Count().SetLimit(1))
+	if err != nil {
 		global.Logger.Error(err)
-		return nil, err
-	} else {
-		return res, nil
+		return primitive.NilObjectID, err
 	}
+	if count == 0 {
+		return primitive.NilObjectID, errors.New("VisibleArticleId: 文章不存在")
+	}
+	return bsonId, nil
 }
 
-// AddPV 增加一次PV
-func (ad *ArticleDao) AddPV(id string) (*mongo.UpdateResult, error) {
+// VisibleArticleSlug 对外可见的文章的id与slug，文章不存在或不可见时返回错误
+func (ad *ArticleDao) VisibleArticleSlug(id string) (primitive.ObjectID, string, error) {
 	bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id))
 	if err != nil {
-		return nil, err
+		return primitive.NilObjectID, "", err
 	}
-	filter := bson.D{{"_id", bsonId}}
-	update := bson.D{{"$inc", bson.D{{"reads_number", 1}}}}
-	if res, err := ad.Collection().UpdateOne(context.TODO(), filter, update); err != nil {
+	var article po.Article
+	filter := append(bson.D{{Key: "_id", Value: bsonId}}, publicVisibleFilter()...)
+	opts := options.FindOne().SetProjection(bson.M{"_id": 1, "slug": 1})
+	if err := ad.Collection().FindOne(context.TODO(), filter, opts).Decode(&article); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return primitive.NilObjectID, "", errors.New("VisibleArticleSlug: 文章不存在")
+		}
 		global.Logger.Error(err)
-		return nil, err
-	} else {
-		return res, nil
+		return primitive.NilObjectID, "", err
 	}
+	return article.Id, article.Slug, nil
 }
 
 // CreateArticle  增加文章
@@ -86,6 +129,55 @@ func (ad *ArticleDao) CreateArticle(input *po.Article) (ans *mongo.InsertOneResu
 	return insertResult, nil
 }
 
+// FindArticleById 通过id查找完整的文章
+func (ad *ArticleDao) FindArticleById(id string) (*po.Article, error) {
+	bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id))
+	if err != nil {
+		return nil, err
+	}
+	var article po.Article
+	if err := ad.Collection().FindOne(context.TODO(), bson.M{"_id": bsonId}).Decode(&article); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("FindArticleById: 文章不存在")
+		}
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return &article, nil
+}
+
+// UpdateArticle 更新文章的内容与元信息
+// 只覆盖可编辑的字段，阅读数、点赞数、评论数与创建时间不在此处修改，避免覆盖并发的计数
+// 置顶状态需要与顺序、到期时间一起修改，由SetOverhead负责
+func (ad *ArticleDao) UpdateArticle(input *po.Article) (*mongo.UpdateResult, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"title":        input.Title,
+			"author":       input.Author,
+			"synopsis":     input.Synopsis,
+			"pic_url":      input.PicUrl,
+			"markdown":     input.Markdown,
+			"md_words":     input.MdWords,
+			"title_words":  input.TitleWords,
+			"draft_flag":   input.DraftFlag,
+			"publish_time": input.PublishTime,
+			"art_length":   input.ArtLength,
+			"content_stat": input.ContentStat,
+			"tags":         input.Tags,
+			"categories":   input.Categories,
+			"update_time":  input.UpdateTime,
+			"slug":         input.Slug,
+			"slug_history": input.SlugHistory,
+		},
+	}
+	res, err := ad.Collection().UpdateByID(context.TODO(), input.Id, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return res, nil
+}
+
 // ArticleBaseSearch 基础权限的文章搜索功能
 // FOLLOWS:
 // - https://www.mongodb.com/docs/drivers/go/current/fundamentals/crud/read-operations/text/
@@ -95,46 +187,17 @@ func (ad *ArticleDao) CreateArticle(input *po.Article) (ans *mongo.InsertOneResu
 func (ad *ArticleDao) ArticleBaseSearch(
 	params vo.BaseArticleSearchVo, id string,
 ) (ans *vo.BaseArticleSearchResultVo, err error) {
-	var result vo.BaseArticleSearchResultVo
-	result.Articles = []vo.SingleBaseArticleSearchResultVo{}
-	pageNumber := params.PageNumber
-	pageSize := params.PageSize
 	filter := ad.getArticleBaseSearchFilter(params, id)
 	opts, err := ad.getArticleBaseSearchOption(params, id)
 	if err != nil {
 		return nil, err
 	}
-	// 防止全量搜索并构造分页, 页码从1开始，需要同时指定才能生效
-	opts = ad.patchPageOption(&pageNumber, &pageSize, opts)
 	global.Logger.Infof("ArticleBaseSearch -> Mongo: \n\t[ %+v | %+v ]", filter, opts)
-	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
-	if err != nil {
-		global.Logger.Error(err)
-		return nil, err
-	}
-	if err = cursor.All(context.TODO(), &result.Articles); err != nil {
-		global.Logger.Error(err)
-		return nil, err
-	}
-	for index, val := range result.Articles {
-		result.Articles[index].UpdateTime = val.UpdateTime.Local()
-		result.Articles[index].CreateTime = val.CreateTime.Local()
-		if params.Lazy {
-			result.Articles[index].Markdown = ""
-		}
+	// 指定id或者模糊搜索时按相关度返回，不做置顶
+	if id != "" || params.SearchText != "" {
+		return ad.findArticlePage(filter, opts, params.BaseParams)
 	}
-	result.PageNumber = pageNumber
-	result.PageSize = pageSize
-	result.AnsCount = int64(len(result.Articles))
-	result.TotalCount = ad.CountDocuments(filter)
-	// defer 关闭游标
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		err := cursor.Close(ctx)
-		if err != nil {
-			global.Logger.Error(err)
-		}
-	}(cursor, context.TODO())
-	return &result, nil
+	return ad.findArticlePageWithOverhead(filter, opts, params.BaseParams)
 }
 
 // ArticleInCategory 某一分类下的文章
@@ -149,12 +212,7 @@ func (ad *ArticleDao) ArticleInCategory(
 	for index, id := range category.ArticleIds {
 		matchIds[index], _ = primitive.ObjectIDFromHex(id)
 	}
-	var result vo.BaseArticleSearchResultVo
-	result.Articles = []vo.SingleBaseArticleSearchResultVo{}
-	pageNumber := params.PageNumber
-	pageSize := params.PageSize
-	filter := bson.D{{"_id", bson.D{{"$in", matchIds}}}}
-	// 防止全量搜索并构造分页, 页码从1开始，需要同时指定才能生效
+	filter := append(bson.D{{Key: "_id", Value: bson.M{"$in": matchIds}}}, publicVisibleFilter()...)
 	opts, err := ad.getArticleBaseSearchOption(vo.BaseArticleSearchVo{
 		SearchText: "",
 		Author:     "",
@@ -169,13 +227,98 @@ func (ad *ArticleDao) ArticleInCategory(
 	if err != nil {
 		return nil, err
 	}
-	opts = ad.patchPageOption(&pageNumber, &pageSize, opts)
+	return ad.findArticlePageWithOverhead(filter, opts, params.BaseParams)
+}
+
+// AdminArticleList admin权限下按发布状态查看文章
+// draft: 草稿; scheduled: 定时发布且尚未到发布时间; published: 已对外可见; 其他: 回收站以外的所有文章
+func (ad *ArticleDao) AdminArticleList(params vo.AdminArticleListVo) (*vo.BaseArticleSearchResultVo, error) {
+	filter := bson.D{{Key: "delete_flag", Value: bson.M{"$ne": true}}}
+	switch params.Status {
+	case "draft":
+		filter = append(filter, bson.E{Key: "draft_flag", Value: true})
+	case "scheduled":
+		filter = append(filter,
+			bson.E{Key: "draft_flag", Value: bson.M{"$ne": true}},
+			bson.E{Key: "publish_time", Value: bson.M{"$gt": time.Now()}},
+		)
+	case "published":
+		filter = publicVisibleFilter()
+	}
+	if params.Author != "" {
+		filter = append(filter, bson.E{Key: "author", Value: params.Author})
+	}
+	opts, err := ad.getArticleBaseSearchOption(vo.BaseArticleSearchVo{BaseParams: params.BaseParams}, "")
+	if err != nil {
+		return nil, err
+	}
+	// 定时发布的文章默认按发布时间先后排列
+	if params.Status == "scheduled" && !params.UpdateTimeSort.SortFlag && !params.CreateTimeSort.SortFlag {
+		opts = opts.SetSort(bson.D{{Key: "publish_time", Value: 1}})
+	}
+	return ad.findArticlePage(filter, opts, params.BaseParams)
+}
 
+// findArticlePageWithOverhead 分页查询文章，第一页的最前面放置生效中的置顶文章
+// 置顶文章不进入常规分页，TotalCount只统计常规文章，保证翻页时不会重复或遗漏
+func (ad *ArticleDao) findArticlePageWithOverhead(
+	filter bson.D, opts *options.FindOptions, params vo.BaseParams,
+) (*vo.BaseArticleSearchResultVo, error) {
+	overhead := activeOverheadFilter(time.Now())
+	result, err := ad.findArticlePage(
+		appendFilter(filter, bson.E{Key: "$nor", Value: bson.A{overhead}}), opts, params,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for index := range result.Articles {
+		// 已过期的置顶按普通文章展示
+		result.Articles[index].Overhead = false
+	}
+	if result.PageNumber != 1 {
+		return result, nil
+	}
+	overheadOpts := options.Find().
+		SetSort(bson.D{{Key: "overhead_order", Value: 1}, {Key: "update_time", Value: -1}}).
+		SetLimit(result.PageSize)
+	if params.Lazy {
+		overheadOpts = overheadOpts.SetProjection(bson.M{"markdown": 0})
+	}
+	overheadResult, err := ad.findArticlePage(
+		appendFilter(filter, bson.E{Key: "$and", Value: bson.A{overhead}}),
+		overheadOpts, vo.BaseParams{Lazy: params.Lazy, PageNumber: 1, PageSize: result.PageSize},
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Articles = append(overheadResult.Articles, result.Articles...)
+	result.AnsCount = int64(len(result.Articles))
+	result.OverheadCount = overheadResult.AnsCount
+	return result, nil
+}
+
+// findArticlePage 按过滤条件与选项分页查询文章
+func (ad *ArticleDao) findArticlePage(
+	filter bson.D, opts *options.FindOptions, params vo.BaseParams,
+) (*vo.BaseArticleSearchResultVo, error) {
+	var result vo.BaseArticleSearchResultVo
+	result.Articles = []vo.SingleBaseArticleSearchResultVo{}
+	pageNumber := params.PageNumber
+	pageSize := params.PageSize
+	// 防止全量搜索并构造分页, 页码从1开始，需要同时指定才能生效
+	opts = ad.patchPageOption(&pageNumber, &pageSize, opts)
 	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
 	if err != nil {
 		global.Logger.Error(err)
 		return nil, err
 	}
+	// defer 关闭游标
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			global.Logger.Error(err)
+		}
+	}(cursor, context.TODO())
 	if err = cursor.All(context.TODO(), &result.Articles); err != nil {
 		global.Logger.Error(err)
 		return nil, err
@@ -183,6 +326,7 @@ func (ad *ArticleDao) ArticleInCategory(
 	for index, val := range result.Articles {
 		result.Articles[index].UpdateTime = val.UpdateTime.Local()
 		result.Articles[index].CreateTime = val.CreateTime.Local()
+		result.Articles[index].PublishTime = val.PublishTime.Local()
 		if params.Lazy {
 			result.Articles[index].Markdown = ""
 		}
@@ -191,13 +335,6 @@ func (ad *ArticleDao) ArticleInCategory(
 	result.PageSize = pageSize
 	result.AnsCount = int64(len(result.Articles))
 	result.TotalCount = ad.CountDocuments(filter)
-	// defer 关闭游标
-	defer func(cursor *mongo.Cursor, ctx context.Context) {
-		err := cursor.Close(ctx)
-		if err != nil {
-			global.Logger.Error(err)
-		}
-	}(cursor, context.TODO())
 	return &result, nil
 }
 
@@ -224,6 +361,13 @@ func (ad *ArticleDao) ArticleCountByUUid(uuid string) int64 {
 // patchPageParams 修正分页Option，并获取pageSkip
 func (ad *ArticleDao) patchPageOption(
 	pageNumber, pageSize *int64, opts *options.FindOptions,
+) *options.FindOptions {
+	return patchPageOption(pageNumber, pageSize, opts)
+}
+
+// patchPageOption 修正分页Option，页码从1开始，页大小默认10、最大200，其他集合的分页也共用
+func patchPageOption(
+	pageNumber, pageSize *int64, opts *options.FindOptions,
 ) *options.FindOptions {
 	if *pageNumber <= 0 {
 		*pageNumber = 1
@@ -299,10 +443,330 @@ func (ad *ArticleDao) getArticleBaseSearchFilter(
 	if searchText != "" && id == "" {
 		filter = append(filter, bson.E{Key: "$text", Value: bson.D{{"$search", searchText}}})
 	}
+	// 回收站中的文章、草稿与未到发布时间的文章对外不可见，id检索也不例外
+	filter = append(filter, publicVisibleFilter()...)
 	return filter
 }
 
-// DeleteArticle 删除文章
+// activeOverheadFilter 生效中的置顶：置顶且没有到期时间或尚未到期
+func activeOverheadFilter(now time.Time) bson.M {
+	return bson.M{
+		"overhead": true,
+		"$or": bson.A{
+			bson.M{"overhead_expire": bson.M{"$exists": false}},
+			bson.M{"overhead_expire": bson.M{"$gt": now}},
+		},
+	}
+}
+
+// appendFilter 复制一份过滤条件再追加，避免多次append共用底层数组
+func appendFilter(filter bson.D, elems ...bson.E) bson.D {
+	ans := make(bson.D, 0, len(filter)+len(elems))
+	ans = append(ans, filter...)
+	return append(ans, elems...)
+}
+
+// publicVisibleFilter 对外可见的文章需要满足的过滤条件：不在回收站、不是草稿、已到发布时间
+// 旧数据可能没有对应的字段，所以使用$ne而不是等于false，没有发布时间的视为已发布
+func publicVisibleFilter() bson.D {
+	return bson.D{
+		{Key: "delete_flag", Value: bson.M{"$ne": true}},
+		{Key: "draft_flag", Value: bson.M{"$ne": true}},
+		{Key: "$or", Value: bson.A{
+			bson.M{"publish_time": bson.M{"$lte": time.Now()}},
+			bson.M{"publish_time": bson.M{"$exists": false}},
+		}},
+	}
+}
+
+// ArticlesBrief 若干文章的标题与状态，用于系列等只需要展示标题的地方，结果的顺序与ids无关
+func (ad *ArticleDao) ArticlesBrief(ids []string) ([]po.Article, error) {
+	articles := []po.Article{}
+	matchIds := make([]primitive.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		if bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id)); err == nil {
+			matchIds = append(matchIds, bsonId)
+		}
+	}
+	if len(matchIds) == 0 {
+		return articles, nil
+	}
+	opts := options.Find().SetProjection(bson.M{
+		"_id": 1, "title": 1, "slug": 1, "draft_flag": 1, "delete_flag": 1, "publish_time": 1,
+	})
+	cursor, err := ad.Collection().Find(context.TODO(), bson.M{"_id": bson.M{"$in": matchIds}}, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// PublishedArticlesBrief 所有对外可见文章的id、分类与更新时间，按更新时间倒序，用于站点地图
+func (ad *ArticleDao) PublishedArticlesBrief() ([]po.Article, error) {
+	articles := []po.Article{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "update_time", Value: -1}}).
+		SetProjection(bson.M{"_id": 1, "categories": 1, "update_time": 1})
+	cursor, err := ad.Collection().Find(context.TODO(), publicVisibleFilter(), opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// ArticlesForRelated 所有对外可见文章的分词、标签与分类，不包含md内容，用于计算相关文章
+func (ad *ArticleDao) ArticlesForRelated() ([]po.Article, error) {
+	articles := []po.Article{}
+	opts := options.Find().SetProjection(bson.M{
+		"_id": 1, "title": 1, "slug": 1, "synopsis": 1, "pic_url": 1, "tags": 1, "categories": 1,
+		"md_words": 1, "title_words": 1, "create_time": 1,
+	})
+	cursor, err := ad.Collection().Find(context.TODO(), publicVisibleFilter(), opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// ArticlesForHot 所有对外可见文章的计数与发布时间，不包含md内容，用于计算热门文章
+func (ad *ArticleDao) ArticlesForHot() ([]po.Article, error) {
+	articles := []po.Article{}
+	opts := options.Find().SetProjection(bson.M{
+		"_id": 1, "title": 1, "slug": 1, "synopsis": 1, "pic_url": 1, "tags": 1,
+		"reads_number": 1, "praise_number": 1, "comments_number": 1, "create_time": 1, "publish_time": 1,
+	})
+	cursor, err := ad.Collection().Find(context.TODO(), publicVisibleFilter(), opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// FindArticleBySlug 通过slug查找对外可见的文章，当前slug优先，其次是曾经使用过的slug
+func (ad *ArticleDao) FindArticleBySlug(slug string) (*po.Article, error) {
+	var article po.Article
+	for _, field := range []string{"slug", "slug_history"} {
+		filter := append(bson.D{{Key: field, Value: slug}}, publicVisibleFilter()...)
+		err := ad.Collection().FindOne(context.TODO(), filter).Decode(&article)
+		if err == nil {
+			return &article, nil
+		}
+		if err != mongo.ErrNoDocuments {
+			global.Logger.Error(err)
+			return nil, err
+		}
+	}
+	return nil, errors.New("FindArticleBySlug: 文章不存在")
+}
+
+// SlugTaken slug是否已被其他文章使用，包括其他文章曾经使用过的slug
+func (ad *ArticleDao) SlugTaken(slug string, exclude primitive.ObjectID) (bool, error) {
+	filter := bson.M{
+		"_id": bson.M{"$ne": exclude},
+		"$or": bson.A{bson.M{"slug": slug}, bson.M{"slug_history": slug}},
+	}
+	count, err := ad.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Error(err)
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// ArticlesWithoutSlug 还没有slug的文章，只返回id与标题
+func (ad *ArticleDao) ArticlesWithoutSlug() ([]po.Article, error) {
+	articles := []po.Article{}
+	filter := bson.M{"$or": bson.A{bson.M{"slug": bson.M{"$exists": false}}, bson.M{"slug": ""}}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "title": 1})
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
+
+// EachArticleMarkdown 逐篇读取所有文章的md内容，包括回收站中的文章，fn返回错误时停止
+func (ad *ArticleDao) EachArticleMarkdown(fn func(article *po.Article) error) error {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "markdown": 1})
+	cursor, err := ad.Collection().Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var article po.Article
+		if err = cursor.Decode(&article); err != nil {
+			global.Logger.Error(err)
+			return err
+		}
+		if err = fn(&article); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+// SetContentStat 设置文章的长度与内容统计
+func (ad *ArticleDao) SetContentStat(id primitive.ObjectID, artLength int64, stat po.ArticleContentStat) error {
+	update := bson.M{"$set": bson.M{"art_length": artLength, "content_stat": stat}}
+	if _, err := ad.Collection().UpdateByID(context.TODO(), id, update); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// SetSlug 设置文章的slug
+func (ad *ArticleDao) SetSlug(id primitive.ObjectID, slug string) error {
+	if _, err := ad.Collection().UpdateByID(context.TODO(), id, bson.M{"$set": bson.M{"slug": slug}}); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// SetCommentsNumber 设置文章的评论数，由评论的审核与删除重新统计之后写入
+func (ad *ArticleDao) SetCommentsNumber(id primitive.ObjectID, count int64) error {
+	update := bson.M{"$set": bson.M{"comments_number": count}}
+	if _, err := ad.Collection().UpdateByID(context.TODO(), id, update); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// SetOverhead 设置文章的置顶状态，取消置顶时一并清除顺序与到期时间
+func (ad *ArticleDao) SetOverhead(
+	id primitive.ObjectID, overhead bool, order int64, expire *time.Time,
+) (*mongo.UpdateResult, error) {
+	var update bson.M
+	if !overhead {
+		update = bson.M{
+			"$set":   bson.M{"overhead": false},
+			"$unset": bson.M{"overhead_order": "", "overhead_expire": ""},
+		}
+	} else if expire == nil || expire.IsZero() {
+		update = bson.M{
+			"$set":   bson.M{"overhead": true, "overhead_order": order},
+			"$unset": bson.M{"overhead_expire": ""},
+		}
+	} else {
+		update = bson.M{
+			"$set": bson.M{"overhead": true, "overhead_order": order, "overhead_expire": *expire},
+		}
+	}
+	res, err := ad.Collection().UpdateByID(context.TODO(), id, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// TrashArticle 将文章移入回收站
+func (ad *ArticleDao) TrashArticle(id primitive.ObjectID) (int64, error) {
+	filter := bson.M{"_id": id, "delete_flag": bson.M{"$ne": true}}
+	update := bson.M{"$set": bson.M{"delete_flag": true, "delete_time": time.Now()}}
+	res, err := ad.Collection().UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// RestoreArticle 将文章移出回收站
+func (ad *ArticleDao) RestoreArticle(id primitive.ObjectID) (int64, error) {
+	filter := bson.M{"_id": id, "delete_flag": true}
+	update := bson.M{
+		"$set":   bson.M{"delete_flag": false},
+		"$unset": bson.M{"delete_time": ""},
+	}
+	res, err := ad.Collection().UpdateOne(context.TODO(), filter, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// TrashedArticles 回收站中的文章，按移入时间倒序，不返回md内容
+func (ad *ArticleDao) TrashedArticles(params vo.BaseParams) (*vo.AdminArticleTrashResultVo, error) {
+	var result vo.AdminArticleTrashResultVo
+	result.Articles = []vo.AdminArticleTrashItemVo{}
+	pageNumber := params.PageNumber
+	pageSize := params.PageSize
+	filter := bson.M{"delete_flag": true}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "delete_time", Value: -1}}).
+		SetProjection(bson.M{"markdown": 0, "md_words": 0, "title_words": 0})
+	opts = ad.patchPageOption(&pageNumber, &pageSize, opts)
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	defer func(cursor *mongo.Cursor, ctx context.Context) {
+		err := cursor.Close(ctx)
+		if err != nil {
+			global.Logger.Error(err)
+		}
+	}(cursor, context.TODO())
+	if err = cursor.All(context.TODO(), &result.Articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	result.PageNumber = pageNumber
+	result.PageSize = pageSize
+	result.AnsCount = int64(len(result.Articles))
+	result.TotalCount = ad.CountDocuments(filter)
+	return &result, nil
+}
+
+// ExpiredTrashArticles 在before之前移入回收站的文章，只返回id与分类
+func (ad *ArticleDao) ExpiredTrashArticles(before time.Time) ([]po.Article, error) {
+	result := []po.Article{}
+	filter := bson.M{"delete_flag": true, "delete_time": bson.M{"$lt": before}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "categories": 1})
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// DeleteArticle 彻底删除文章
 func (ad *ArticleDao) DeleteArticle(id string) (int64, error) {
 	if bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id)); err != nil {
 		global.Logger.Error(err)
@@ -0,0 +1,55 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 导出文章需要的查询
+ * @File:  article_export_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+)
+
+// EachArticle 按创建时间逐篇遍历文章，不会一次性加载所有文章，fn返回错误时停止遍历
+// withTrash为false时跳过回收站中的文章
+func (ad *ArticleDao) EachArticle(withTrash bool, fn func(article *po.Article) error) error {
+	filter := bson.M{}
+	if !withTrash {
+		filter["delete_flag"] = bson.M{"$ne": true}
+	}
+	opts := options.Find().
+		SetProjection(bson.M{"md_words": 0, "title_words": 0}).
+		SetSort(bson.D{{Key: "create_time", Value: 1}, {Key: "_id", Value: 1}})
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	defer cursor.Close(context.TODO())
+	for cursor.Next(context.TODO()) {
+		var article po.Article
+		if err = cursor.Decode(&article); err != nil {
+			global.Logger.Error(err)
+			return err
+		}
+		article.CreateTime = article.CreateTime.Local()
+		article.UpdateTime = article.UpdateTime.Local()
+		article.PublishTime = article.PublishTime.Local()
+		article.DeleteTime = article.DeleteTime.Local()
+		if err = fn(&article); err != nil {
+			return err
+		}
+	}
+	if err = cursor.Err(); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
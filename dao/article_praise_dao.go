@@ -0,0 +1,66 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章点赞记录相关的DAO
+ * @File:  article_praise_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"time"
+)
+
+type ArticlePraiseDao struct {
+	*BasicDaoMongo `R0Ioc:"true"`
+}
+
+func (*ArticlePraiseDao) CollectionName() string {
+	return "article_praises"
+}
+func (pd *ArticlePraiseDao) Collection() *mongo.Collection {
+	return pd.Mdb.Collection(pd.CollectionName())
+}
+
+// AddPraise 记录一次点赞，访客已经点过赞时返回false，依赖article_id与visitor的唯一索引
+func (pd *ArticlePraiseDao) AddPraise(articleId primitive.ObjectID, visitor string) (bool, error) {
+	_, err := pd.Collection().InsertOne(context.TODO(), po.ArticlePraise{
+		ArticleId: articleId, Visitor: visitor, CreateTime: time.Now(),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, nil
+	}
+	if err != nil {
+		global.Logger.Error(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// RemovePraise 取消点赞，访客没有点过赞时返回false
+func (pd *ArticlePraiseDao) RemovePraise(articleId primitive.ObjectID, visitor string) (bool, error) {
+	res, err := pd.Collection().DeleteOne(context.TODO(), bson.M{"article_id": articleId, "visitor": visitor})
+	if err != nil {
+		global.Logger.Error(err)
+		return false, err
+	}
+	return res.DeletedCount > 0, nil
+}
+
+// DeleteArticlePraises 删除一篇文章的所有点赞记录
+func (pd *ArticlePraiseDao) DeleteArticlePraises(articleId primitive.ObjectID) (int64, error) {
+	res, err := pd.Collection().DeleteMany(context.TODO(), bson.M{"article_id": articleId})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
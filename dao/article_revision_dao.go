@@ -0,0 +1,118 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章历史版本相关的DAO
+ * @File:  article_revision_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"time"
+)
+
+type ArticleRevisionDao struct {
+	*BasicDaoMongo `R0Ioc:"true"`
+}
+
+func (*ArticleRevisionDao) CollectionName() string {
+	return "article_revisions"
+}
+func (rd *ArticleRevisionDao) Collection() *mongo.Collection {
+	return rd.Mdb.Collection(rd.CollectionName())
+}
+
+// CreateRevision 保存一次快照，版本号在已有的最新版本上递增
+func (rd *ArticleRevisionDao) CreateRevision(revision *po.ArticleRevision) error {
+	version, err := rd.LatestVersion(revision.ArticleId)
+	if err != nil {
+		return err
+	}
+	revision.Version = version + 1
+	revision.CreateTime = time.Now()
+	insertResult, err := rd.Collection().InsertOne(context.TODO(), revision)
+	if err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	revision.Id = insertResult.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// LatestVersion 文章最新的版本号，没有历史版本时为0
+func (rd *ArticleRevisionDao) LatestVersion(articleId primitive.ObjectID) (int64, error) {
+	var latest po.ArticleRevision
+	opts := options.FindOne().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.M{"version": 1})
+	err := rd.Collection().FindOne(context.TODO(), bson.M{"article_id": articleId}, opts).Decode(&latest)
+	if err == mongo.ErrNoDocuments {
+		return 0, nil
+	}
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return latest.Version, nil
+}
+
+// ListRevisions 文章的所有历史版本，按版本号倒序，不返回md内容
+func (rd *ArticleRevisionDao) ListRevisions(articleId primitive.ObjectID) ([]po.ArticleRevision, error) {
+	result := []po.ArticleRevision{}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetProjection(bson.M{"markdown": 0})
+	cursor, err := rd.Collection().Find(context.TODO(), bson.M{"article_id": articleId}, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// FindRevision 查找文章的某一个版本
+func (rd *ArticleRevisionDao) FindRevision(articleId primitive.ObjectID, version int64) (*po.ArticleRevision, error) {
+	var revision po.ArticleRevision
+	filter := bson.M{"article_id": articleId, "version": version}
+	if err := rd.Collection().FindOne(context.TODO(), filter).Decode(&revision); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("FindRevision: 版本不存在")
+		}
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// DeleteRevision 删除一个快照，用于撤销没有生效的修改留下的快照
+func (rd *ArticleRevisionDao) DeleteRevision(id primitive.ObjectID) error {
+	if _, err := rd.Collection().DeleteOne(context.TODO(), bson.M{"_id": id}); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// DeleteRevisions 删除文章的所有历史版本
+func (rd *ArticleRevisionDao) DeleteRevisions(articleId primitive.ObjectID) (int64, error) {
+	deleteRes, err := rd.Collection().DeleteMany(context.TODO(), bson.M{"article_id": articleId})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return deleteRes.DeletedCount, nil
+}
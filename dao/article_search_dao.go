@@ -0,0 +1,88 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 进程内全文索引需要的文章查询
+ * @File:  article_search_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+)
+
+// IndexableArticleVersions 回收站以外所有文章的id与更新时间，用于判断索引是否需要更新
+func (ad *ArticleDao) IndexableArticleVersions() ([]po.Article, error) {
+	return ad.findArticles(
+		bson.M{"delete_flag": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{"_id": 1, "update_time": 1}),
+	)
+}
+
+// IndexableArticles 回收站以外的若干文章中需要索引的内容
+func (ad *ArticleDao) IndexableArticles(ids []primitive.ObjectID) ([]po.Article, error) {
+	return ad.findArticles(
+		bson.M{"_id": bson.M{"$in": ids}, "delete_flag": bson.M{"$ne": true}},
+		options.Find().SetProjection(bson.M{
+			"_id": 1, "title": 1, "tags": 1, "markdown": 1, "update_time": 1,
+		}),
+	)
+}
+
+// VisibleArticlesAmong 若干文章中对外可见的文章的id与时间，author不为空时只保留该作者的文章
+func (ad *ArticleDao) VisibleArticlesAmong(ids []primitive.ObjectID, author string) ([]po.Article, error) {
+	filter := append(bson.D{{Key: "_id", Value: bson.M{"$in": ids}}}, publicVisibleFilter()...)
+	if author != "" {
+		filter = append(filter, bson.E{Key: "author", Value: author})
+	}
+	return ad.findArticles(filter, options.Find().SetProjection(bson.M{
+		"_id": 1, "create_time": 1, "update_time": 1,
+	}))
+}
+
+// ArticlesByIds 若干文章的完整内容，结果的顺序与ids无关，lazy时不返回md内容
+func (ad *ArticleDao) ArticlesByIds(ids []primitive.ObjectID, lazy bool) ([]vo.SingleBaseArticleSearchResultVo, error) {
+	articles := []vo.SingleBaseArticleSearchResultVo{}
+	opts := options.Find()
+	if lazy {
+		opts = opts.SetProjection(bson.M{"markdown": 0})
+	}
+	cursor, err := ad.Collection().Find(context.TODO(), bson.M{"_id": bson.M{"$in": ids}}, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	for index, val := range articles {
+		articles[index].UpdateTime = val.UpdateTime.Local()
+		articles[index].CreateTime = val.CreateTime.Local()
+		articles[index].PublishTime = val.PublishTime.Local()
+	}
+	return articles, nil
+}
+
+// findArticles 按过滤条件查询文章，不分页
+func (ad *ArticleDao) findArticles(filter interface{}, opts *options.FindOptions) ([]po.Article, error) {
+	articles := []po.Article{}
+	cursor, err := ad.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &articles); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return articles, nil
+}
@@ -0,0 +1,149 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章每日统计相关的DAO
+ * @File:  article_stat_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"strings"
+	"time"
+)
+
+// statKeyEscaper 分布的键作为字段名写入，"."与"$"在字段名中有特殊含义，需要转义
+var statKeyEscaper = strings.NewReplacer("%", "%25", ".", "%2E", "$", "%24")
+var statKeyUnescaper = strings.NewReplacer("%2E", ".", "%24", "$", "%25", "%")
+
+type ArticleStatDao struct {
+	*BasicDaoMongo `R0Ioc:"true"`
+}
+
+func (*ArticleStatDao) CollectionName() string {
+	return "article_stats"
+}
+func (sd *ArticleStatDao) Collection() *mongo.Collection {
+	return sd.Mdb.Collection(sd.CollectionName())
+}
+
+// IncArticleStats 批量累加各分桶的统计，分桶不存在时创建
+// 出错时返回写入失败的分桶，已经写入的不包含在内，避免重试时重复累加
+func (sd *ArticleStatDao) IncArticleStats(
+	deltas map[bo.ArticleStatKey]*bo.ArticleStatDelta,
+) (map[bo.ArticleStatKey]*bo.ArticleStatDelta, error) {
+	models := make([]mongo.WriteModel, 0, len(deltas))
+	keys := make([]bo.ArticleStatKey, 0, len(deltas))
+	for key, delta := range deltas {
+		date, err := time.ParseInLocation("2006-01-02", key.Day, time.Local)
+		if err != nil {
+			global.Logger.Error(err)
+			continue
+		}
+		inc := bson.M{"views": delta.Views}
+		for field, counts := range map[string]map[string]int64{
+			"referrers": delta.Referrers, "paths": delta.Paths, "devices": delta.Devices, "browsers": delta.Browsers,
+		} {
+			for name, count := range counts {
+				if name != "" {
+					inc[field+"."+statKeyEscaper.Replace(name)] = count
+				}
+			}
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"article_id": key.ArticleId, "day": key.Day}).
+			SetUpdate(bson.M{"$inc": inc, "$setOnInsert": bson.M{"date": date}}).
+			SetUpsert(true))
+		keys = append(keys, key)
+	}
+	if len(models) == 0 {
+		return nil, nil
+	}
+	if _, err := sd.Collection().BulkWrite(context.TODO(), models, options.BulkWrite().SetOrdered(false)); err != nil {
+		global.Logger.Error(err)
+		failed := map[bo.ArticleStatKey]*bo.ArticleStatDelta{}
+		for _, index := range failedBulkWrites(err, len(models)) {
+			failed[keys[index]] = deltas[keys[index]]
+		}
+		return failed, err
+	}
+	return nil, nil
+}
+
+// ArticleStatsBetween [start, end)之间的所有分桶，articleId不为空时只查询该文章
+func (sd *ArticleStatDao) ArticleStatsBetween(
+	start, end time.Time, articleId *primitive.ObjectID,
+) ([]po.ArticleStat, error) {
+	filter := bson.M{"date": bson.M{"$gte": start, "$lt": end}}
+	if articleId != nil {
+		filter["article_id"] = *articleId
+	}
+	stats := []po.ArticleStat{}
+	cursor, err := sd.Collection().Find(context.TODO(), filter, options.Find().SetSort(bson.M{"date": 1}))
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &stats); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	for index := range stats {
+		for _, counts := range []*map[string]int64{
+			&stats[index].Referrers, &stats[index].Paths, &stats[index].Devices, &stats[index].Browsers,
+		} {
+			*counts = unescapeStatKeys(*counts)
+		}
+	}
+	return stats, nil
+}
+
+// TopArticlesBetween [start, end)之间阅读数最多的文章
+func (sd *ArticleStatDao) TopArticlesBetween(start, end time.Time, limit int64) ([]vo.AdminArticleTopItemVo, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"date": bson.M{"$gte": start, "$lt": end}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$article_id", "views": bson.M{"$sum": "$views"}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "views", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+	items := []vo.AdminArticleTopItemVo{}
+	cursor, err := sd.Collection().Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &items); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return items, nil
+}
+
+// DeleteArticleStats 删除一篇文章的所有统计
+func (sd *ArticleStatDao) DeleteArticleStats(articleId primitive.ObjectID) (int64, error) {
+	res, err := sd.Collection().DeleteMany(context.TODO(), bson.M{"article_id": articleId})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
+
+func unescapeStatKeys(counts map[string]int64) map[string]int64 {
+	result := make(map[string]int64, len(counts))
+	for name, count := range counts {
+		result[statKeyUnescaper.Replace(name)] += count
+	}
+	return result
+}
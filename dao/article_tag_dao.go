@@ -0,0 +1,114 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章标签相关的查询，标签直接存放在文章的tags中，没有单独的集合
+ * @File:  article_tag_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/vo"
+)
+
+// ArticleTagStats 标签及其文章数，按文章数倒序，publicOnly时只统计对外可见的文章，否则统计回收站以外的文章
+func (ad *ArticleDao) ArticleTagStats(publicOnly bool) ([]vo.ArticleTagCountVo, error) {
+	result := []vo.ArticleTagCountVo{}
+	match := bson.D{{Key: "delete_flag", Value: bson.M{"$ne": true}}}
+	if publicOnly {
+		match = publicVisibleFilter()
+	}
+	pipeline := bson.A{
+		bson.M{"$match": match},
+		bson.M{"$unwind": "$tags"},
+		bson.M{"$match": bson.M{"tags": bson.M{"$ne": ""}}},
+		bson.M{"$group": bson.M{
+			"_id":              "$tags",
+			"count":            bson.M{"$sum": 1},
+			"last_update_time": bson.M{"$max": "$update_time"},
+		}},
+		bson.M{"$sort": bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}},
+	}
+	cursor, err := ad.Collection().Aggregate(context.TODO(), pipeline)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	for index, val := range result {
+		result[index].LastUpdateTime = val.LastUpdateTime.Local()
+	}
+	return result, nil
+}
+
+// ArticleInTag 某一标签下对外可见的文章，分页与排序同ArticleInCategory
+func (ad *ArticleDao) ArticleInTag(params vo.ArticleSearchByTagVo) (*vo.BaseArticleSearchResultVo, error) {
+	filter := append(bson.D{{Key: "tags", Value: params.Tag}}, publicVisibleFilter()...)
+	opts, err := ad.getArticleBaseSearchOption(vo.BaseArticleSearchVo{BaseParams: params.BaseParams}, "")
+	if err != nil {
+		return nil, err
+	}
+	return ad.findArticlePageWithOverhead(filter, opts, params.BaseParams)
+}
+
+// MergeArticleTags 将文章中的from标签替换为to，已经带有to的文章直接移除from，避免出现重复的标签
+func (ad *ArticleDao) MergeArticleTags(from []string, to string) (int64, error) {
+	return ad.mergeArticleValues("tags", from, to)
+}
+
+// ArticleIdsWithTags 带有任意一个标签的文章id
+func (ad *ArticleDao) ArticleIdsWithTags(tags []string) ([]primitive.ObjectID, error) {
+	return ad.articleIdsWithValues("tags", tags)
+}
+
+// mergeArticleValues 将文章中数组字段field的from替换为to，to追加在数组末尾且不会重复
+// 旧数据中的数组可能有重复的值，先$addToSet再$pull可以替换所有出现的位置
+func (ad *ArticleDao) mergeArticleValues(field string, from []string, to string) (int64, error) {
+	var modified int64
+	for _, value := range from {
+		if value == to {
+			continue
+		}
+		filter := bson.D{{Key: field, Value: value}}
+		if _, err := ad.Collection().UpdateMany(context.TODO(), filter,
+			bson.M{"$addToSet": bson.M{field: to}},
+		); err != nil {
+			global.Logger.Error(err)
+			return modified, err
+		}
+		pullRes, err := ad.Collection().UpdateMany(context.TODO(), filter,
+			bson.M{"$pull": bson.M{field: value}},
+		)
+		if err != nil {
+			global.Logger.Error(err)
+			return modified, err
+		}
+		modified += pullRes.ModifiedCount
+	}
+	return modified, nil
+}
+
+// articleIdsWithValues 数组字段field中带有任意一个值的文章id
+func (ad *ArticleDao) articleIdsWithValues(field string, values []string) ([]primitive.ObjectID, error) {
+	articles, err := ad.findArticles(
+		bson.M{field: bson.M{"$in": values}}, options.Find().SetProjection(bson.M{"_id": 1}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(articles))
+	for index, val := range articles {
+		ids[index] = val.Id
+	}
+	return ids, nil
+}
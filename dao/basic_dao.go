@@ -11,6 +11,7 @@ package dao
 
 import (
 	"context"
+	"errors"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -32,6 +33,23 @@ func (bd *BasicDaoMongo) Collection() *mongo.Collection {
 	return bd.Mdb.Collection(bd.CollectionName())
 }
 
+// failedBulkWrites 无序批量写入中失败的模型下标，无法确定哪些失败时视为全部失败
+func failedBulkWrites(err error, count int) []int {
+	var bulkErr mongo.BulkWriteException
+	if errors.As(err, &bulkErr) && bulkErr.WriteConcernError == nil && len(bulkErr.WriteErrors) > 0 {
+		indexes := make([]int, 0, len(bulkErr.WriteErrors))
+		for _, val := range bulkErr.WriteErrors {
+			indexes = append(indexes, val.Index)
+		}
+		return indexes
+	}
+	indexes := make([]int, count)
+	for index := range indexes {
+		indexes[index] = index
+	}
+	return indexes
+}
+
 func (bd *BasicDaoMongo) Disconnect() {
 	ctx := context.Background()
 	if err := bd.Mc.Disconnect(ctx); err != nil {
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"r0Website-server/global"
 	"r0Website-server/models/bo"
 	"r0Website-server/models/po"
@@ -65,10 +66,11 @@ func (cd *CategoryDao) AddCategory(name string) (*mongo.InsertOneResult, error)
 	return insertResult, nil
 }
 
-// AllCategories 所有的分类
+// AllCategories 所有的分类，按排序序号与名称排列
 func (cd *CategoryDao) AllCategories() (*vo.CategorySearchResultVo, error) {
 	res := &vo.CategorySearchResultVo{}
-	cursor, err := cd.Collection().Find(context.TODO(), bson.D{})
+	opts := options.Find().SetSort(bson.D{{Key: "sort_order", Value: 1}, {Key: "name", Value: 1}})
+	cursor, err := cd.Collection().Find(context.TODO(), bson.D{}, opts)
 	if err != nil {
 		global.Logger.Error(err)
 		return nil, err
@@ -87,7 +89,7 @@ func (cd *CategoryDao) ArchiveArticle(articleId string, name string) (*mongo.Upd
 		return nil, err
 	} else if !exists {
 		if addResult, addErr := cd.AddCategory(name); addErr != nil {
-			return nil, err
+			return nil, addErr
 		} else {
 			global.Logger.Infof("new Category:%s-%v", name, addResult.InsertedID)
 		}
@@ -132,3 +134,46 @@ func (cd *CategoryDao) RemoveArticle(categories []string, id string) (*mongo.Upd
 		return deleteRes, nil
 	}
 }
+
+// CreateCategory 新建分类，调用方需要先确认分类不存在
+func (cd *CategoryDao) CreateCategory(input *po.Category) (*mongo.InsertOneResult, error) {
+	if input.ArticleIds == nil {
+		input.ArticleIds = []string{}
+	}
+	insertResult, err := cd.Collection().InsertOne(context.TODO(), input)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return insertResult, nil
+}
+
+// UpdateCategory 修改分类的名称、描述、封面与排序序号
+func (cd *CategoryDao) UpdateCategory(name string, set bson.M) (*mongo.UpdateResult, error) {
+	res, err := cd.Collection().UpdateMany(context.TODO(), bson.M{"name": name}, bson.M{"$set": set})
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// SetCategoryArticles 覆盖分类下的文章id与文章数
+func (cd *CategoryDao) SetCategoryArticles(name string, articleIds []string) error {
+	update := bson.M{"$set": bson.M{"article_ids": articleIds, "count": int64(len(articleIds))}}
+	if _, err := cd.Collection().UpdateMany(context.TODO(), bson.M{"name": name}, update); err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	return nil
+}
+
+// DeleteCategories 删除若干分类，不修改文章
+func (cd *CategoryDao) DeleteCategories(names []string) (int64, error) {
+	res, err := cd.Collection().DeleteMany(context.TODO(), bson.M{"name": bson.M{"$in": names}})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.DeletedCount, nil
+}
@@ -0,0 +1,218 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章评论相关的DAO
+ * @File:  comment_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"time"
+)
+
+type CommentDao struct {
+	*BasicDaoMongo `R0Ioc:"true"`
+}
+
+func (*CommentDao) CollectionName() string {
+	return "comments"
+}
+func (cd *CommentDao) Collection() *mongo.Collection {
+	return cd.Mdb.Collection(cd.CollectionName())
+}
+
+// CreateComment 保存一条评论
+func (cd *CommentDao) CreateComment(comment *po.Comment) error {
+	insertResult, err := cd.Collection().InsertOne(context.TODO(), comment)
+	if err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	comment.Id = insertResult.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindCommentById 通过id查找评论
+func (cd *CommentDao) FindCommentById(id string) (*po.Comment, error) {
+	objId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id))
+	if err != nil {
+		return nil, err
+	}
+	var comment po.Comment
+	if err = cd.Collection().FindOne(context.TODO(), bson.M{"_id": objId}).Decode(&comment); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("FindCommentById: 评论不存在")
+		}
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// ApprovedRootComments 文章下通过审核的顶层评论，按时间正序分页
+func (cd *CommentDao) ApprovedRootComments(
+	articleId primitive.ObjectID, pageNumber, pageSize *int64,
+) ([]po.Comment, int64, error) {
+	comments := []po.Comment{}
+	filter := bson.M{
+		"article_id": articleId,
+		"status":     po.CommentStatusApproved,
+		"root_id":    bson.M{"$exists": false},
+	}
+	opts := patchPageOption(pageNumber, pageSize, options.Find().SetSort(bson.D{{Key: "create_time", Value: 1}}))
+	cursor, err := cd.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, 0, err
+	}
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		global.Logger.Error(err)
+		return nil, 0, err
+	}
+	total, err := cd.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, 0, err
+	}
+	return comments, total, nil
+}
+
+// ApprovedReplies 若干楼层下通过审核的回复，按时间正序
+func (cd *CommentDao) ApprovedReplies(rootIds []primitive.ObjectID) ([]po.Comment, error) {
+	comments := []po.Comment{}
+	if len(rootIds) == 0 {
+		return comments, nil
+	}
+	filter := bson.M{
+		"root_id": bson.M{"$in": rootIds},
+		"status":  po.CommentStatusApproved,
+	}
+	opts := options.Find().SetSort(bson.D{{Key: "create_time", Value: 1}})
+	cursor, err := cd.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &comments); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return comments, nil
+}
+
+// CountApproved 文章下通过审核的评论数，包括回复
+func (cd *CommentDao) CountApproved(articleId primitive.ObjectID) (int64, error) {
+	filter := bson.M{"article_id": articleId, "status": po.CommentStatusApproved}
+	count, err := cd.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// AdminCommentList 管理员查看评论，按创建时间倒序
+func (cd *CommentDao) AdminCommentList(params vo.AdminCommentListVo) (*vo.AdminCommentListResultVo, error) {
+	result := vo.AdminCommentListResultVo{Comments: []vo.AdminCommentVo{}}
+	filter := bson.M{}
+	if params.Status != "" {
+		filter["status"] = params.Status
+	}
+	if params.ArticleId != "" {
+		articleId, err := primitive.ObjectIDFromHex(utils.String2HexString24(params.ArticleId))
+		if err != nil {
+			return nil, err
+		}
+		filter["article_id"] = articleId
+	}
+	pageNumber, pageSize := params.PageNumber, params.PageSize
+	opts := patchPageOption(&pageNumber, &pageSize, options.Find().SetSort(bson.D{{Key: "create_time", Value: -1}}))
+	cursor, err := cd.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result.Comments); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	for index, val := range result.Comments {
+		result.Comments[index].CreateTime = val.CreateTime.Local()
+		result.Comments[index].UpdateTime = val.UpdateTime.Local()
+	}
+	total, err := cd.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	result.PageNumber = pageNumber
+	result.PageSize = pageSize
+	result.AnsCount = int64(len(result.Comments))
+	result.TotalCount = total
+	return &result, nil
+}
+
+// SetCommentStatus 修改评论的审核状态
+func (cd *CommentDao) SetCommentStatus(id primitive.ObjectID, status string) (int64, error) {
+	update := bson.M{"$set": bson.M{"status": status, "update_time": time.Now()}}
+	res, err := cd.Collection().UpdateByID(context.TODO(), id, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
+
+// ChildCommentIds 直接回复了parentIds中任一评论的评论id
+func (cd *CommentDao) ChildCommentIds(parentIds []primitive.ObjectID) ([]primitive.ObjectID, error) {
+	var children []po.Comment
+	filter := bson.M{"parent_id": bson.M{"$in": parentIds}}
+	opts := options.Find().SetProjection(bson.M{"_id": 1})
+	cursor, err := cd.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &children); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	ids := make([]primitive.ObjectID, len(children))
+	for index, val := range children {
+		ids[index] = val.Id
+	}
+	return ids, nil
+}
+
+// DeleteComments 删除若干评论
+func (cd *CommentDao) DeleteComments(ids []primitive.ObjectID) (int64, error) {
+	deleteRes, err := cd.Collection().DeleteMany(context.TODO(), bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return deleteRes.DeletedCount, nil
+}
+
+// DeleteArticleComments 删除文章下的所有评论
+func (cd *CommentDao) DeleteArticleComments(articleId primitive.ObjectID) (int64, error) {
+	deleteRes, err := cd.Collection().DeleteMany(context.TODO(), bson.M{"article_id": articleId})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return deleteRes.DeletedCount, nil
+}
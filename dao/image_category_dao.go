@@ -117,6 +117,24 @@ func (cd *ImageCategoryDao) ListCategories() ([]*po.ImageCategory, error) {
 	return categories, nil
 }
 
+// ListCategoriesBrief 获取所有分类的id与更新时间，不带图片引用列表，用于站点地图
+func (cd *ImageCategoryDao) ListCategoriesBrief() ([]*po.ImageCategory, error) {
+	opts := options.Find().SetProjection(bson.M{"_id": 1, "name": 1, "updatedAt": 1})
+	cursor, err := cd.Collection().Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		global.Logger.Errorf("❌ 获取图片分类列表失败: %v", err)
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var categories []*po.ImageCategory
+	if err = cursor.All(context.TODO(), &categories); err != nil {
+		global.Logger.Errorf("❌ 解析图片分类列表失败: %v", err)
+		return nil, err
+	}
+	return categories, nil
+}
+
 // AddImageToCategory 添加图片到分类（维护倒排索引）
 func (cd *ImageCategoryDao) AddImageToCategory(categoryID string, imageID primitive.ObjectID, sortOrder int) error {
 	// 获取当前最大排序序号
@@ -343,4 +361,4 @@ func (cd *ImageCategoryDao) EnsureDefaultCategories() error {
 	}
 
 	return nil
-}
\ No newline at end of file
+}
\ No newline at end of file
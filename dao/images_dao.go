@@ -5,8 +5,10 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 	"r0Website-server/global"
 	"r0Website-server/models/po"
+	"regexp"
 	"time"
 )
 
@@ -114,6 +116,50 @@ func (id *ImageDao) SearchImagesByName(keyword string) ([]*po.Image, error) {
 	return imgs, err
 }
 
+// SearchImagesByNameLimit 按图片名搜索，关键字按字面匹配，按上传时间倒序，同时返回命中的总数
+func (id *ImageDao) SearchImagesByNameLimit(keyword string, limit int64) ([]*po.Image, int64, error) {
+	filter := bson.M{"name": bson.M{"$regex": regexp.QuoteMeta(keyword), "$options": "i"}}
+	opts := options.Find().
+		SetProjection(bson.M{"positions": 0, "exif": 0}).
+		SetSort(bson.M{"uploaded_at": -1}).
+		SetLimit(limit)
+	cursor, err := id.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Errorf("❌ 图片搜索失败: %v", err)
+		return nil, 0, err
+	}
+	imgs := []*po.Image{}
+	if err = cursor.All(context.TODO(), &imgs); err != nil {
+		global.Logger.Errorf("❌ 解析搜索结果失败: %v", err)
+		return nil, 0, err
+	}
+	total, err := id.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Errorf("❌ 统计图片失败: %v", err)
+		return nil, 0, err
+	}
+	return imgs, total, nil
+}
+
+// FindImagesByUrls 原图或缩略图地址在urls中的图片
+func (id *ImageDao) FindImagesByUrls(urls []string) ([]*po.Image, error) {
+	filter := bson.M{"$or": bson.A{
+		bson.M{"cos_url": bson.M{"$in": urls}},
+		bson.M{"thumb_url": bson.M{"$in": urls}},
+	}}
+	cursor, err := id.Collection().Find(context.TODO(), filter, options.Find().SetProjection(bson.M{"positions": 0}))
+	if err != nil {
+		global.Logger.Errorf("❌ 查询图片失败: %v", err)
+		return nil, err
+	}
+	imgs := []*po.Image{}
+	if err = cursor.All(context.TODO(), &imgs); err != nil {
+		global.Logger.Errorf("❌ 解析图片失败: %v", err)
+		return nil, err
+	}
+	return imgs, nil
+}
+
 // UpdateImageCategoryPosition 更新图片在分类中的位置信息
 func (id *ImageDao) UpdateImageCategoryPosition(imageID primitive.ObjectID, categoryID string, position *po.CategoryPosition) error {
 	update := bson.M{
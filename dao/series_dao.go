@@ -0,0 +1,149 @@
+// Package dao
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章系列相关的DAO
+ * @File:  series_dao
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package dao
+
+import (
+	"context"
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/utils"
+)
+
+type SeriesDao struct {
+	*BasicDaoMongo `R0Ioc:"true"`
+}
+
+func (*SeriesDao) CollectionName() string {
+	return "series"
+}
+func (sd *SeriesDao) Collection() *mongo.Collection {
+	return sd.Mdb.Collection(sd.CollectionName())
+}
+
+// CreateSeries 新建系列
+func (sd *SeriesDao) CreateSeries(series *po.Series) error {
+	insertResult, err := sd.Collection().InsertOne(context.TODO(), series)
+	if err != nil {
+		global.Logger.Error(err)
+		return err
+	}
+	series.Id = insertResult.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// FindSeriesById 通过id查找系列
+func (sd *SeriesDao) FindSeriesById(id string) (*po.Series, error) {
+	bsonId, err := primitive.ObjectIDFromHex(utils.String2HexString24(id))
+	if err != nil {
+		return nil, err
+	}
+	var series po.Series
+	if err = sd.Collection().FindOne(context.TODO(), bson.M{"_id": bsonId}).Decode(&series); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, errors.New("FindSeriesById: 系列不存在")
+		}
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return &series, nil
+}
+
+// FindSeriesByArticle 文章所在的系列，不属于任何系列时返回nil
+func (sd *SeriesDao) FindSeriesByArticle(articleId string) (*po.Series, error) {
+	var series po.Series
+	err := sd.Collection().FindOne(context.TODO(), bson.M{"article_ids": articleId}).Decode(&series)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return &series, nil
+}
+
+// SeriesContainingArticles 包含了articleIds中任一文章的其他系列，exclude为零值时不排除
+func (sd *SeriesDao) SeriesContainingArticles(articleIds []string, exclude primitive.ObjectID) ([]po.Series, error) {
+	result := []po.Series{}
+	filter := bson.M{"article_ids": bson.M{"$in": articleIds}}
+	if !exclude.IsZero() {
+		filter["_id"] = bson.M{"$ne": exclude}
+	}
+	cursor, err := sd.Collection().Find(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// AllSeries 所有系列，按更新时间倒序
+func (sd *SeriesDao) AllSeries() ([]po.Series, error) {
+	result := []po.Series{}
+	opts := options.Find().SetSort(bson.D{{Key: "update_time", Value: -1}})
+	cursor, err := sd.Collection().Find(context.TODO(), bson.M{}, opts)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	if err = cursor.All(context.TODO(), &result); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// UpdateSeries 更新系列的标题、说明与文章顺序
+func (sd *SeriesDao) UpdateSeries(series *po.Series) (*mongo.UpdateResult, error) {
+	update := bson.M{
+		"$set": bson.M{
+			"title":       series.Title,
+			"description": series.Description,
+			"article_ids": series.ArticleIds,
+			"update_time": series.UpdateTime,
+		},
+	}
+	res, err := sd.Collection().UpdateByID(context.TODO(), series.Id, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// DeleteSeries 删除系列，文章本身不受影响
+func (sd *SeriesDao) DeleteSeries(id primitive.ObjectID) (int64, error) {
+	deleteRes, err := sd.Collection().DeleteOne(context.TODO(), bson.M{"_id": id})
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return deleteRes.DeletedCount, nil
+}
+
+// RemoveArticle 将文章从所有系列中移除，文章被彻底删除时使用
+func (sd *SeriesDao) RemoveArticle(articleId string) (int64, error) {
+	update := bson.M{"$pull": bson.M{"article_ids": articleId}}
+	res, err := sd.Collection().UpdateMany(context.TODO(), bson.M{"article_ids": articleId}, update)
+	if err != nil {
+		global.Logger.Error(err)
+		return 0, err
+	}
+	return res.ModifiedCount, nil
+}
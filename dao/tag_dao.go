@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"r0Website-server/global"
 	"r0Website-server/models/po"
+	"regexp"
 	"strings"
 	"time"
 )
@@ -288,6 +289,32 @@ func (td *TagDao) GetPopularTags(limit int, category string) ([]*po.TagStats, er
 	return stats, nil
 }
 
+// SearchTagsByKeyword 按标签名或显示名搜索，关键字按字面匹配，按图片数倒序，同时返回命中的总数
+func (td *TagDao) SearchTagsByKeyword(keyword string, limit int64) ([]*po.Tag, int64, error) {
+	pattern := bson.M{"$regex": regexp.QuoteMeta(keyword), "$options": "i"}
+	filter := bson.M{"$or": bson.A{bson.M{"name": pattern}, bson.M{"displayName": pattern}}}
+	opts := options.Find().
+		SetProjection(bson.M{"images": 0}).
+		SetSort(bson.M{"imageCount": -1}).
+		SetLimit(limit)
+	cursor, err := td.Collection().Find(context.TODO(), filter, opts)
+	if err != nil {
+		global.Logger.Errorf("❌ 搜索标签失败: %v", err)
+		return nil, 0, err
+	}
+	tags := []*po.Tag{}
+	if err = cursor.All(context.TODO(), &tags); err != nil {
+		global.Logger.Errorf("❌ 解析搜索结果失败: %v", err)
+		return nil, 0, err
+	}
+	total, err := td.Collection().CountDocuments(context.TODO(), filter)
+	if err != nil {
+		global.Logger.Errorf("❌ 统计标签失败: %v", err)
+		return nil, 0, err
+	}
+	return tags, total, nil
+}
+
 // GetOrCreateTag 获取或创建标签
 func (td *TagDao) GetOrCreateTag(name string, displayName string, category string) (*po.Tag, error) {
 	tagID := strings.ToLower(strings.TrimSpace(name))
@@ -371,4 +398,4 @@ func (td *TagDao) SyncImageTags(imageID primitive.ObjectID, imageName string, ol
 	}
 
 	return nil
-}
\ No newline at end of file
+}
\ No newline at end of file
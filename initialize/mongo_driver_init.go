@@ -88,6 +88,11 @@ func MongoConstructor(cfg *config.Mongo) *dao.BasicDaoMongo {
 	} else {
 		fmt.Printf("✅ Pic-related Mongo indexes ensured\n")
 	}
+	if err := InitArticleMongoIndexes(db); err != nil {
+		fmt.Printf("⚠ InitArticleMongoIndexes failed: %v\n", err)
+	} else {
+		fmt.Printf("✅ Article-related Mongo indexes ensured\n")
+	}
 	return &dao.BasicDaoMongo{Mc: client, Mdb: client.Database(cfg.DB)}
 }
 
@@ -99,9 +104,6 @@ type PicIndexSpec struct {
 
 // InitPicMongoIndexes 初始化图集项目相关的索引，幂等并极致压缩时间
 func InitPicMongoIndexes(db *mongo.Database) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
-
 	// 1. 定义所有需要的索引
 	requiredIndexes := []PicIndexSpec{
 		// images 索引
@@ -143,6 +145,83 @@ func InitPicMongoIndexes(db *mongo.Database) error {
 			Options: options.Index().SetName("idx_image_refs_image_id"),
 		}},
 	}
+	return ensureMongoIndexes(db, requiredIndexes)
+}
+
+// InitArticleMongoIndexes 初始化文章相关的索引
+func InitArticleMongoIndexes(db *mongo.Database) error {
+	requiredIndexes := []PicIndexSpec{
+		// article_revisions 索引
+		{"article_revisions", mongo.IndexModel{
+			Keys:    bson.D{{Key: "article_id", Value: 1}, {Key: "version", Value: -1}},
+			Options: options.Index().SetUnique(true).SetName("idx_article_version_unique"),
+		}},
+		// comments 索引
+		{"comments", mongo.IndexModel{
+			Keys: bson.D{
+				{Key: "article_id", Value: 1}, {Key: "status", Value: 1}, {Key: "create_time", Value: 1},
+			},
+			Options: options.Index().SetName("idx_article_status_time"),
+		}},
+		{"comments", mongo.IndexModel{
+			Keys:    bson.D{{Key: "root_id", Value: 1}, {Key: "create_time", Value: 1}},
+			Options: options.Index().SetName("idx_root_time"),
+		}},
+		{"comments", mongo.IndexModel{
+			Keys:    bson.D{{Key: "parent_id", Value: 1}},
+			Options: options.Index().SetName("idx_parent"),
+		}},
+		{"comments", mongo.IndexModel{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "create_time", Value: -1}},
+			Options: options.Index().SetName("idx_status_time"),
+		}},
+		// articles slug 索引，没有slug的旧文章不参与唯一约束
+		{"articles", mongo.IndexModel{
+			Keys: bson.D{{Key: "slug", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_slug_unique").
+				SetPartialFilterExpression(bson.M{"slug": bson.M{"$type": "string"}}),
+		}},
+		{"articles", mongo.IndexModel{
+			Keys:    bson.D{{Key: "slug_history", Value: 1}},
+			Options: options.Index().SetName("idx_slug_history"),
+		}},
+		// $text检索依赖的全文索引，分词在写入时已经完成，因此不使用语言相关的词干处理
+		{"articles", mongo.IndexModel{
+			Keys: bson.D{{Key: "title_words", Value: "text"}, {Key: "md_words", Value: "text"}},
+			Options: options.Index().SetName("idx_text_title_md_words").
+				SetWeights(bson.M{"title_words": 3, "md_words": 1}).SetDefaultLanguage("none"),
+		}},
+		{"articles", mongo.IndexModel{
+			Keys:    bson.D{{Key: "tags", Value: 1}, {Key: "update_time", Value: -1}},
+			Options: options.Index().SetName("idx_tags_update"),
+		}},
+		// article_praises 索引，同一访客对同一文章只能点赞一次
+		{"article_praises", mongo.IndexModel{
+			Keys:    bson.D{{Key: "article_id", Value: 1}, {Key: "visitor", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_article_visitor_unique"),
+		}},
+		// article_stats 索引，每篇文章每天一个分桶
+		{"article_stats", mongo.IndexModel{
+			Keys:    bson.D{{Key: "article_id", Value: 1}, {Key: "day", Value: 1}},
+			Options: options.Index().SetUnique(true).SetName("idx_article_day_unique"),
+		}},
+		{"article_stats", mongo.IndexModel{
+			Keys:    bson.D{{Key: "date", Value: 1}},
+			Options: options.Index().SetName("idx_date"),
+		}},
+		// series 索引
+		{"series", mongo.IndexModel{
+			Keys:    bson.D{{Key: "article_ids", Value: 1}},
+			Options: options.Index().SetName("idx_article_ids"),
+		}},
+	}
+	return ensureMongoIndexes(db, requiredIndexes)
+}
+
+// ensureMongoIndexes 创建缺失的索引，已存在的索引直接跳过
+func ensureMongoIndexes(db *mongo.Database, requiredIndexes []PicIndexSpec) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
 	// 2. 扫描所有已存在索引（每个集合只查一次）
 	existingIndexNames := make(map[string]map[string]bool)
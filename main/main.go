@@ -37,6 +37,7 @@ func main() {
 	initialize.InitLogger()
 	initialize.InitUtils()
 	r0Ioc.InitR0Ioc(global.YAMLPATH)
-	defer r0Ioc.ExitR0Ioc()
 	core.RunWindowsServer()
+	// 服务关闭之后再退出容器，写入计数器中尚未写入的增量
+	r0Ioc.ExitR0Ioc()
 }
@@ -0,0 +1,43 @@
+// Package bo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章计数器与统计的增量
+ * @File:  article_counter
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package bo
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// ArticleCounterDelta 一篇文章在两次写入之间累积的计数增量
+type ArticleCounterDelta struct {
+	Reads   int64 // 阅读数
+	Praises int64 // 点赞数，取消点赞时为负
+}
+
+// ArticleVisit 一次访问的来源信息
+type ArticleVisit struct {
+	Ip        string // 访客IP
+	UserAgent string // 访客UA
+	Token     string // 客户端保存的访客令牌，可以为空
+	Referrer  string // 访客进入站点之前的页面，即前台的document.referrer
+	Page      string // 访客阅读文章的前台页面
+	Origin    string // 请求的来源站点，未配置站点地址时用于识别站内来源
+}
+
+// ArticleStatKey 统计的分桶：一篇文章的一天
+type ArticleStatKey struct {
+	ArticleId primitive.ObjectID
+	Day       string // 本地时间的日期 2006-01-02
+}
+
+// ArticleStatDelta 一个分桶在两次写入之间累积的增量
+type ArticleStatDelta struct {
+	Views     int64
+	Referrers map[string]int64 // 来源站点
+	Paths     map[string]int64 // 阅读文章的前台路径
+	Devices   map[string]int64 // 设备类型
+	Browsers  map[string]int64 // 浏览器
+}
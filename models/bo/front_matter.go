@@ -0,0 +1,48 @@
+// Package bo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 从Hexo/Hugo/Jekyll的front matter中解析出的文章元信息
+ * @File:  front_matter
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package bo
+
+import "time"
+
+// FrontMatter 为空的字段表示front matter中没有提供
+type FrontMatter struct {
+	Title       string
+	Author      string
+	Synopsis    string    // description/excerpt/summary
+	Slug        string    // slug，Jekyll/Hexo的permalink不是slug，不使用
+	PicUrl      string    // cover/image/thumbnail/banner
+	Tags        []string  // tags，字符串时按逗号或空格分隔
+	Categories  []string  // categories/category，Hexo的多级分类会被展开
+	Draft       bool      // draft: true 或 published: false
+	Deleted     bool      // deleted: true，本站导出时在回收站中的文章
+	Date        time.Time // date，文章最初的创建时间
+	Updated     time.Time // updated/lastmod
+	PublishDate time.Time // publishDate/publish_date，Hugo的定时发布
+}
+
+// ArticleFrontMatter 导出文章时写入的front matter，键名与FrontMatter能识别的一致，导出的文件可以原样导入
+type ArticleFrontMatter struct {
+	Title       string    `yaml:"title"`
+	Slug        string    `yaml:"slug,omitempty"`
+	Author      string    `yaml:"author,omitempty"`
+	Date        time.Time `yaml:"date"`
+	Updated     time.Time `yaml:"updated"`
+	PublishDate time.Time `yaml:"publishDate,omitempty"`
+	Tags        []string  `yaml:"tags"`
+	Categories  []string  `yaml:"categories"`
+	Draft       bool      `yaml:"draft,omitempty"`
+	Description string    `yaml:"description,omitempty"`
+	Cover       string    `yaml:"cover,omitempty"`
+	Overhead    bool      `yaml:"overhead,omitempty"`
+	Deleted     bool      `yaml:"deleted,omitempty"` // 导出时在回收站中
+	Reads       int64     `yaml:"reads"`
+	Comments    int64     `yaml:"comments"`
+	Praises     int64     `yaml:"praises"`
+}
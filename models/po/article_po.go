@@ -22,18 +22,38 @@ type Article struct {
 	Synopsis string `bson:"synopsis"` // 备注
 	PicUrl   string `bson:"pic_url"`  // 图片的链接
 	// Detail         string             `bson:"detail"`          // htm内容
-	Markdown       string    `bson:"markdown"`        // md内容
-	MdWords        string    `bson:"md_words"`        // md分词内容
-	TitleWords     string    `bson:"title_words"`     // 标题分词内容
-	DeleteFlag     bool      `bson:"delete_flag"`     // 是否已删除
-	DraftFlag      bool      `bson:"draft_flag"`      // 是否为草稿
-	Overhead       bool      `bson:"overhead"`        // 是否置顶
-	ArtLength      int64     `bson:"art_length"`      // 文章长度
-	ReadsNumber    int64     `bson:"reads_number"`    // 阅读数
-	CommentsNumber int64     `bson:"comments_number"` // 评论数
-	PraiseNumber   int64     `bson:"praise_number"`   // 点赞数
-	Tags           []string  `bson:"tags"`            // 标签
-	Categories     []string  `bson:"categories"`      // 分类
-	CreateTime     time.Time `bson:"create_time"`     // 创建时间
-	UpdateTime     time.Time `bson:"update_time"`     // 更新时间
+	Markdown       string    `bson:"markdown"`                  // md内容
+	MdWords        string    `bson:"md_words"`                  // md分词内容
+	TitleWords     string    `bson:"title_words"`               // 标题分词内容
+	DeleteFlag     bool      `bson:"delete_flag"`               // 是否已删除
+	DraftFlag      bool      `bson:"draft_flag"`                // 是否为草稿
+	Overhead       bool      `bson:"overhead"`                  // 是否置顶
+	OverheadOrder  int64     `bson:"overhead_order"`            // 置顶顺序，越小越靠前
+	OverheadExpire time.Time `bson:"overhead_expire,omitempty"` // 置顶的到期时间，为空时永久置顶
+	ArtLength      int64     `bson:"art_length"`                // 文章长度
+	ReadsNumber    int64     `bson:"reads_number"`              // 阅读数
+	CommentsNumber int64     `bson:"comments_number"`           // 评论数
+	PraiseNumber   int64     `bson:"praise_number"`             // 点赞数
+	Tags           []string  `bson:"tags"`                      // 标签
+	Categories     []string  `bson:"categories"`                // 分类
+	CreateTime     time.Time `bson:"create_time"`               // 创建时间
+	UpdateTime     time.Time `bson:"update_time"`               // 更新时间
+	PublishTime    time.Time `bson:"publish_time"`              // 发布时间，未到发布时间的文章对外不可见
+	DeleteTime     time.Time `bson:"delete_time,omitempty"`     // 移入回收站的时间
+	Slug           string    `bson:"slug,omitempty"`            // 可读的唯一标识，由标题生成
+	SlugHistory    []string  `bson:"slug_history,omitempty"`    // 曾经使用过的slug，访问时重定向到当前slug
+	// 内容统计与估算的阅读时长，随md内容一起更新
+	ContentStat ArticleContentStat `bson:"content_stat"`
+}
+
+// ArticleContentStat 由md内容统计的字数、链接、图片、代码行数与估算的阅读时长
+type ArticleContentStat struct {
+	Words          int64 `json:"words" bson:"words"`                     // 字数，不含标点，包含代码块
+	Puncts         int64 `json:"puncts" bson:"puncts"`                   // 标点数
+	CJKChars       int64 `json:"cjk_chars" bson:"cjk_chars"`             // 代码块以外的中日韩文字数
+	LatinWords     int64 `json:"latin_words" bson:"latin_words"`         // 代码块以外英文等以空格分词的单词数
+	Links          int64 `json:"links" bson:"links"`                     // 链接数
+	Pics           int64 `json:"pics" bson:"pics"`                       // 图片数
+	CodeLines      int64 `json:"code_lines" bson:"code_lines"`           // 代码块的行数
+	ReadingMinutes int64 `json:"reading_minutes" bson:"reading_minutes"` // 估算的阅读分钟数
 }
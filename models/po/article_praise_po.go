@@ -0,0 +1,23 @@
+// Package po
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章点赞记录的模型
+ * @File:  article_praise_po
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package po
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ArticlePraise 一位访客对一篇文章的点赞，同一访客对同一文章只保留一条
+type ArticlePraise struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"` // Mongo 主键 _id
+	ArticleId  primitive.ObjectID `bson:"article_id"`    // 文章id
+	Visitor    string             `bson:"visitor"`       // 访客指纹，由客户端令牌或IP与UA计算，不保存原始值
+	CreateTime time.Time          `bson:"create_time"`   // 点赞时间
+}
@@ -0,0 +1,36 @@
+// Package po
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章历史版本的模型
+ * @File:  article_revision_po
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package po
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ArticleRevision 文章的历史版本，每次保存之前对旧的内容与元信息做一次快照
+type ArticleRevision struct {
+	Id                primitive.ObjectID `bson:"_id,omitempty"`       // Mongo 主键 _id
+	ArticleId         primitive.ObjectID `bson:"article_id"`          // 所属文章
+	Version           int64              `bson:"version"`             // 版本号，同一篇文章内从1开始递增
+	Reason            string             `bson:"reason"`              // 快照原因 update/overwrite/restore/backup
+	Title             string             `bson:"title"`               // 文章标题
+	Author            string             `bson:"author"`              // 作者
+	Synopsis          string             `bson:"synopsis"`            // 备注
+	PicUrl            string             `bson:"pic_url"`             // 图片的链接
+	Markdown          string             `bson:"markdown"`            // md内容
+	DraftFlag         bool               `bson:"draft_flag"`          // 是否为草稿
+	Overhead          bool               `bson:"overhead"`            // 是否置顶
+	PublishTime       time.Time          `bson:"publish_time"`        // 发布时间
+	ArtLength         int64              `bson:"art_length"`          // 文章长度
+	Tags              []string           `bson:"tags"`                // 标签
+	Categories        []string           `bson:"categories"`          // 分类
+	ArticleUpdateTime time.Time          `bson:"article_update_time"` // 快照内容对应的文章更新时间
+	CreateTime        time.Time          `bson:"create_time"`         // 快照时间
+}
@@ -0,0 +1,29 @@
+// Package po
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章每日统计的模型
+ * @File:  article_stat_po
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package po
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ArticleStat 一篇文章一天的统计，每篇文章每天一个文档
+// 各分布的键中的"."与"$"会被转义，读取时需要还原
+type ArticleStat struct {
+	Id        primitive.ObjectID `bson:"_id,omitempty"`       // Mongo 主键 _id
+	ArticleId primitive.ObjectID `bson:"article_id"`          // 文章id
+	Day       string             `bson:"day"`                 // 本地时间的日期 2006-01-02
+	Date      time.Time          `bson:"date"`                // 当天的零点，用于按时间范围查询
+	Views     int64              `bson:"views"`               // 去重之后的阅读数
+	Referrers map[string]int64   `bson:"referrers,omitempty"` // 来源站点，direct为直接访问，internal为站内跳转
+	Paths     map[string]int64   `bson:"paths,omitempty"`     // 阅读文章的前台路径
+	Devices   map[string]int64   `bson:"devices,omitempty"`   // 设备类型 desktop/mobile/tablet/bot
+	Browsers  map[string]int64   `bson:"browsers,omitempty"`  // 浏览器
+}
@@ -8,4 +8,8 @@ type Category struct {
 	Name       string             `json:"name" bson:"name"`
 	Count      int64              `json:"count" bson:"count"`
 	ArticleIds []string           `json:"article_ids" bson:"article_ids"`
+	// 展示用的描述、封面与排序序号，序号越小越靠前
+	Description string `json:"description" bson:"description"`
+	Cover       string `json:"cover" bson:"cover"`
+	SortOrder   int64  `json:"sort_order" bson:"sort_order"`
 }
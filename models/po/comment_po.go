@@ -0,0 +1,40 @@
+// Package po
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章评论的模型
+ * @File:  comment_po
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package po
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// 评论的审核状态，只有通过审核的评论对外可见并计入文章的评论数
+const (
+	CommentStatusPending  = "pending"  // 待审核
+	CommentStatusApproved = "approved" // 已通过
+	CommentStatusRejected = "rejected" // 已拒绝
+	CommentStatusSpam     = "spam"     // 垃圾评论
+)
+
+// Comment 文章的评论，通过ParentId形成楼中楼
+type Comment struct {
+	Id         primitive.ObjectID `bson:"_id,omitempty"`       // Mongo 主键 _id
+	ArticleId  primitive.ObjectID `bson:"article_id"`          // 所属文章
+	ParentId   primitive.ObjectID `bson:"parent_id,omitempty"` // 回复的评论，顶层评论为空
+	RootId     primitive.ObjectID `bson:"root_id,omitempty"`   // 所在楼层的顶层评论，顶层评论为空
+	Name       string             `bson:"name"`                // 访客昵称
+	Email      string             `bson:"email"`               // 访客邮箱，不对外展示
+	Website    string             `bson:"website"`             // 访客网站
+	Content    string             `bson:"content"`             // 评论内容，纯文本
+	Status     string             `bson:"status"`              // 审核状态
+	Ip         string             `bson:"ip"`                  // 评论者ip
+	UserAgent  string             `bson:"user_agent"`          // 评论者UA
+	CreateTime time.Time          `bson:"create_time"`         // 创建时间
+	UpdateTime time.Time          `bson:"update_time"`         // 审核状态的更新时间
+}
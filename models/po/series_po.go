@@ -0,0 +1,25 @@
+// Package po
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章系列的模型
+ * @File:  series_po
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package po
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// Series 文章系列，把拆分成多篇的长文按顺序串起来，一篇文章最多属于一个系列
+type Series struct {
+	Id          primitive.ObjectID `bson:"_id,omitempty"` // Mongo 主键 _id
+	Title       string             `bson:"title"`         // 系列标题
+	Description string             `bson:"description"`   // 系列说明
+	ArticleIds  []string           `bson:"article_ids"`   // 按阅读顺序排列的文章id
+	CreateTime  time.Time          `bson:"create_time"`   // 创建时间
+	UpdateTime  time.Time          `bson:"update_time"`   // 更新时间
+}
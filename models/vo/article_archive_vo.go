@@ -0,0 +1,47 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章按日期归档的视图模型
+ * @File:  article_archive_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "time"
+
+// ArticleArchiveItemVo 归档中的文章摘要
+type ArticleArchiveItemVo struct {
+	Id         string    `json:"id" bson:"_id"`
+	Title      string    `json:"title" bson:"title"`
+	Slug       string    `json:"slug,omitempty" bson:"slug,omitempty"`
+	CreateTime time.Time `json:"create_time" bson:"create_time"`
+}
+
+// ArticleArchiveMonthVo 某一月份的归档
+type ArticleArchiveMonthVo struct {
+	Month    int                    `json:"month"`
+	Count    int64                  `json:"count"`
+	Articles []ArticleArchiveItemVo `json:"articles"` // 按创建时间倒序
+}
+
+// ArticleArchiveYearVo 某一年份的归档
+type ArticleArchiveYearVo struct {
+	Year   int                     `json:"year"`
+	Count  int64                   `json:"count"`
+	Months []ArticleArchiveMonthVo `json:"months"` // 按月份倒序
+}
+
+// ArticleArchiveVo 文章按创建时间的归档
+type ArticleArchiveVo struct {
+	Years      []ArticleArchiveYearVo `json:"years"` // 按年份倒序
+	TotalCount int64                  `json:"total_count"`
+}
+
+// ArticleSearchByMonthVo 某一月份创建的文章
+type ArticleSearchByMonthVo struct {
+	BaseParams
+	Year  int `json:"year"`
+	Month int `json:"month"`
+}
@@ -0,0 +1,47 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站导出视图模型
+ * @File:  article_export_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "time"
+
+// AdminArticleExportVo 导出的选项
+type AdminArticleExportVo struct {
+	Images bool `form:"images"` // 是否同时导出文章引用的图床图片
+	Trash  bool `form:"trash"`  // 是否同时导出回收站中的文章
+}
+
+// ArticleExportManifestVo 导出包中的manifest.json
+type ArticleExportManifestVo struct {
+	FormatVersion int                    `json:"format_version"` // 导出格式的版本
+	ExportedAt    time.Time              `json:"exported_at"`    // 导出时间
+	Articles      []ArticleExportItemVo  `json:"articles"`       // 导出的文章
+	Categories    int64                  `json:"categories"`     // 导出的分类数
+	Images        []ArticleExportImageVo `json:"images"`         // 导出的图片，未导出图片时为空
+}
+
+// ArticleExportItemVo 一篇导出的文章
+type ArticleExportItemVo struct {
+	Id      string `json:"_id"`               // 文章的id
+	File    string `json:"file"`              // 在导出包中的路径
+	Title   string `json:"title"`             // 文章标题
+	Slug    string `json:"slug,omitempty"`    // 文章的slug
+	Draft   bool   `json:"draft,omitempty"`   // 是否为草稿
+	Deleted bool   `json:"deleted,omitempty"` // 是否在回收站中
+}
+
+// ArticleExportImageVo 一张导出的图片
+type ArticleExportImageVo struct {
+	Id       string `json:"_id"`             // 图片的id
+	Name     string `json:"name"`            // 图片名称
+	Url      string `json:"url"`             // 原图地址
+	ThumbUrl string `json:"thumb_url"`       // 缩略图地址
+	File     string `json:"file,omitempty"`  // 在导出包中的路径，下载失败时为空
+	Error    string `json:"error,omitempty"` // 下载失败的原因
+}
@@ -0,0 +1,38 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 热门文章的视图模型
+ * @File:  article_hot_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "time"
+
+// BaseArticleHotVo 查询热门文章的参数
+type BaseArticleHotVo struct {
+	Size int `json:"size" form:"size"` // 返回的数量，默认10篇
+}
+
+// ArticleHotVo 一篇热门文章
+type ArticleHotVo struct {
+	Id             string    `json:"id"`
+	Title          string    `json:"title"`
+	Slug           string    `json:"slug,omitempty"`
+	Synopsis       string    `json:"synopsis"`
+	PicUrl         string    `json:"pic_url"`
+	Tags           []string  `json:"tags"`
+	ReadsNumber    int64     `json:"reads_number"`
+	PraiseNumber   int64     `json:"praise_number"`
+	CommentsNumber int64     `json:"comments_number"`
+	CreateTime     time.Time `json:"create_time"`
+	Score          float64   `json:"score"` // 随时间衰减的热度，越大越热门
+}
+
+// ArticleHotResultVo 热门文章
+type ArticleHotResultVo struct {
+	Articles  []ArticleHotVo `json:"articles"`   // 按热度倒序
+	UpdatedAt time.Time      `json:"updated_at"` // 热度的计算时间
+}
@@ -0,0 +1,36 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章批量导入视图模型
+ * @File:  article_import_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "mime/multipart"
+
+// AdminArticleImportVo 上传一个包含md文件的zip压缩包
+type AdminArticleImportVo struct {
+	File   *multipart.FileHeader `form:"file" binding:"required"` // zip压缩包
+	Author string                `form:"author"`                  // 作者，为空时为当前用户，front matter中的author不会覆盖它
+}
+
+// AdminArticleImportItemVo 单个文件的导入结果
+type AdminArticleImportItemVo struct {
+	File    string `json:"file"`              // 文件在压缩包中的路径
+	Id      string `json:"_id,omitempty"`     // 导入成功时文章的id
+	Title   string `json:"title,omitempty"`   // 文章标题
+	Slug    string `json:"slug,omitempty"`    // 文章的slug
+	Trashed bool   `json:"trashed,omitempty"` // front matter中标记为deleted，导入之后移入回收站
+	Error   string `json:"error,omitempty"`   // 导入失败的原因
+}
+
+// AdminArticleImportResultVo 批量导入的结果，压缩包中md以外的文件会被忽略
+type AdminArticleImportResultVo struct {
+	Total     int64                      `json:"total"`     // md文件数
+	Succeeded int64                      `json:"succeeded"` // 导入成功的文件数
+	Failed    int64                      `json:"failed"`    // 导入失败的文件数
+	Results   []AdminArticleImportItemVo `json:"results"`   // 每个文件的结果，与压缩包中的顺序一致
+}
@@ -0,0 +1,34 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 相关文章推荐的视图模型
+ * @File:  article_related_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "time"
+
+// BaseArticleRelatedVo 查询相关文章的参数
+type BaseArticleRelatedVo struct {
+	Size int `json:"size" form:"size"` // 返回的数量，为空时使用配置的数量
+}
+
+// ArticleRelatedVo 一篇相关文章
+type ArticleRelatedVo struct {
+	Id         string    `json:"id"`
+	Title      string    `json:"title"`
+	Slug       string    `json:"slug,omitempty"`
+	Synopsis   string    `json:"synopsis"`
+	PicUrl     string    `json:"pic_url"`
+	Tags       []string  `json:"tags"`
+	CreateTime time.Time `json:"create_time"`
+	Score      float64   `json:"score"` // 相关度，越大越相关
+}
+
+// ArticleRelatedResultVo 相关文章
+type ArticleRelatedResultVo struct {
+	Articles []ArticleRelatedVo `json:"articles"` // 按相关度倒序
+}
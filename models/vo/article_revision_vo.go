@@ -0,0 +1,63 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章历史版本视图模型
+ * @File:  article_revision_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// ArticleRevisionVo 单个历史版本
+type ArticleRevisionVo struct {
+	Id                primitive.ObjectID `json:"_id"`                 // Mongo 主键 _id
+	ArticleId         primitive.ObjectID `json:"article_id"`          // 所属文章
+	Version           int64              `json:"version"`             // 版本号
+	Reason            string             `json:"reason"`              // 快照原因
+	Title             string             `json:"title"`               // 文章标题
+	Author            string             `json:"author"`              // 作者
+	Synopsis          string             `json:"synopsis"`            // 备注
+	PicUrl            string             `json:"pic_url"`             // 图片的链接
+	Markdown          string             `json:"markdown"`            // md内容，列表中不返回
+	DraftFlag         bool               `json:"draft_flag"`          // 是否为草稿
+	Overhead          bool               `json:"overhead"`            // 是否置顶
+	PublishTime       time.Time          `json:"publish_time"`        // 发布时间
+	ArtLength         int64              `json:"art_length"`          // 文章长度
+	Tags              []string           `json:"tags"`                // 标签
+	Categories        []string           `json:"categories"`          // 分类
+	ArticleUpdateTime time.Time          `json:"article_update_time"` // 快照内容对应的文章更新时间
+	CreateTime        time.Time          `json:"create_time"`         // 快照时间
+}
+
+// ArticleRevisionListVo 文章历史版本列表
+type ArticleRevisionListVo struct {
+	ArticleId  primitive.ObjectID  `json:"article_id"`  // 所属文章
+	Revisions  []ArticleRevisionVo `json:"revisions"`   // 版本列表，按版本号倒序
+	TotalCount int64               `json:"total_count"` // 总数
+}
+
+// ArticleRevisionDiffParamsVo 比较两个版本的参数，版本号为0时表示文章当前的内容
+type ArticleRevisionDiffParamsVo struct {
+	From int64 `json:"from" form:"from"` // 旧版本号
+	To   int64 `json:"to" form:"to"`     // 新版本号
+}
+
+// ArticleRevisionDiffVo 两个版本之间的unified diff
+type ArticleRevisionDiffVo struct {
+	ArticleId primitive.ObjectID `json:"article_id"` // 所属文章
+	From      int64              `json:"from"`       // 旧版本号
+	To        int64              `json:"to"`         // 新版本号
+	Diff      string             `json:"diff"`       // unified diff，内容一致时为空串
+}
+
+// ArticleRevisionRestoreResultVo 恢复历史版本之后的返回模型
+type ArticleRevisionRestoreResultVo struct {
+	AdminArticleUpdateResultVo
+	RestoredVersion int64 `json:"restored_version"` // 被恢复的版本号
+}
@@ -0,0 +1,64 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章统计视图模型
+ * @File:  article_stat_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+// AdminArticleStatsVo 按日期范围查询统计，日期为本地时间 2006-01-02，包含首尾两天
+type AdminArticleStatsVo struct {
+	Start string `form:"start"` // 开始日期，为空时为结束日期之前的第29天
+	End   string `form:"end"`   // 结束日期，为空时为今天
+}
+
+// AdminArticleStatsResultVo 日期范围内的统计
+type AdminArticleStatsResultVo struct {
+	ArticleId string                `json:"article_id,omitempty"` // 为空时为全站的统计
+	Start     string                `json:"start"`
+	End       string                `json:"end"`
+	Views     int64                 `json:"views"`     // 范围内的阅读数
+	Days      []ArticleStatsDayVo   `json:"days"`      // 每天的阅读数，没有阅读的日期为0
+	Referrers []ArticleStatsCountVo `json:"referrers"` // 来源站点 direct为直接访问 internal为站内跳转
+	Paths     []ArticleStatsCountVo `json:"paths"`     // 阅读文章的前台路径
+	Devices   []ArticleStatsCountVo `json:"devices"`   // 设备类型
+	Browsers  []ArticleStatsCountVo `json:"browsers"`  // 浏览器
+}
+
+// ArticleStatsDayVo 一天的阅读数
+type ArticleStatsDayVo struct {
+	Date  string `json:"date"`
+	Views int64  `json:"views"`
+}
+
+// ArticleStatsCountVo 分布中的一项
+type ArticleStatsCountVo struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// AdminArticleTopVo 最近一段时间阅读数最多的文章
+type AdminArticleTopVo struct {
+	Days int64 `form:"days"` // 包含今天在内的天数，默认7天
+	Size int64 `form:"size"` // 数量，默认10篇
+}
+
+// AdminArticleTopItemVo 一篇文章及其阅读数
+type AdminArticleTopItemVo struct {
+	Id    primitive.ObjectID `json:"_id" bson:"_id"`
+	Title string             `json:"title" bson:"-"`
+	Slug  string             `json:"slug,omitempty" bson:"-"`
+	Views int64              `json:"views" bson:"views"`
+}
+
+// AdminArticleTopResultVo 阅读数排行
+type AdminArticleTopResultVo struct {
+	Start    string                  `json:"start"`
+	End      string                  `json:"end"`
+	Articles []AdminArticleTopItemVo `json:"articles"`
+}
@@ -0,0 +1,49 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章标签视图模型
+ * @File:  article_tag_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "time"
+
+// ArticleSearchByTagVo 某一标签下的文章
+type ArticleSearchByTagVo struct {
+	BaseParams
+	Tag string `json:"tag"`
+}
+
+// ArticleTagCountVo 标签及其文章数
+type ArticleTagCountVo struct {
+	Tag            string    `json:"tag" bson:"_id"`                           // 标签
+	Count          int64     `json:"count" bson:"count"`                       // 文章数
+	LastUpdateTime time.Time `json:"last_update_time" bson:"last_update_time"` // 标签下文章最新的更新时间
+}
+
+// ArticleTagListVo 所有标签
+type ArticleTagListVo struct {
+	Tags       []ArticleTagCountVo `json:"tags"`        // 按文章数倒序
+	TotalCount int64               `json:"total_count"` // 标签数
+}
+
+// AdminArticleTagRenameVo 重命名标签
+type AdminArticleTagRenameVo struct {
+	Name string `json:"name" form:"name" binding:"required"` // 新的标签名，已存在时等同于合并
+}
+
+// AdminArticleTagMergeVo 合并标签
+type AdminArticleTagMergeVo struct {
+	From []string `json:"from" form:"from" binding:"required"` // 被合并的标签
+	To   string   `json:"to" form:"to" binding:"required"`     // 合并到的标签
+}
+
+// AdminArticleTagResultVo 重命名或合并标签之后的返回模型
+type AdminArticleTagResultVo struct {
+	From          []string `json:"from"`           // 被合并的标签
+	To            string   `json:"to"`             // 合并到的标签
+	ModifiedCount int64    `json:"modified_count"` // 被修改的文章数
+}
@@ -13,6 +13,8 @@ import (
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"mime/multipart"
 	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
+	"r0Website-server/utils"
 	"time"
 )
 
@@ -38,6 +40,67 @@ type AdminArticleAddMetaVo struct {
 	DraftFlag  bool     `form:"draft_flag"` // 是否为草稿
 	Overhead   bool     `form:"overhead"`   // 是否顶置
 	PicUrl     string   `form:"pic_url"`    // 图片的链接
+	Slug       string   `form:"slug"`       // 自定义的slug，为空时由标题生成
+	// 发布时间 RFC3339格式，未指定时立即发布，指定未来的时间即为定时发布
+	PublishTime time.Time `form:"publish_time"`
+	// 创建时间 RFC3339格式，仅对新文章生效，用于导入时保留文章原本的日期，未指定时为当前时间
+	CreateTime time.Time `form:"create_time"`
+	// 更新时间 RFC3339格式，仅对新文章生效，未指定时与创建时间相同
+	UpdateTime time.Time `form:"update_time"`
+}
+
+// AdminArticleUpdateVo admin权限下article局部更新的模型
+// 字段均为指针，未提供的字段保持原值；计数器与创建时间不允许通过此模型修改
+type AdminArticleUpdateVo struct {
+	Title      *string   `json:"title" form:"title"`           // 文章标题
+	Author     *string   `json:"author" form:"author"`         // 主人
+	Synopsis   *string   `json:"synopsis" form:"synopsis"`     // 备注
+	Markdown   *string   `json:"markdown" form:"markdown"`     // 文章的md数据
+	Tags       *[]string `json:"tags" form:"tags"`             // 标签
+	Categories *[]string `json:"categories" form:"categories"` // 分类
+	DraftFlag  *bool     `json:"draft_flag" form:"draft_flag"` // 是否为草稿
+	Overhead   *bool     `json:"overhead" form:"overhead"`     // 是否顶置
+	PicUrl     *string   `json:"pic_url" form:"pic_url"`       // 图片的链接
+	Slug       *string   `json:"slug" form:"slug"`             // 自定义的slug，为空串时按标题重新生成
+	// 发布时间 RFC3339格式
+	PublishTime *time.Time `json:"publish_time" form:"publish_time"`
+}
+
+// AdminArticleOverheadVo 文章置顶的模型
+type AdminArticleOverheadVo struct {
+	Overhead   bool       `json:"overhead" form:"overhead"`       // 置顶或取消置顶
+	Order      int64      `json:"order" form:"order"`             // 置顶顺序，越小越靠前
+	ExpireTime *time.Time `json:"expire_time" form:"expire_time"` // 置顶的到期时间 RFC3339格式，为空时永久置顶
+}
+
+// AdminArticleOverheadResultVo 文章置顶之后的返回模型
+type AdminArticleOverheadResultVo struct {
+	Id            primitive.ObjectID `json:"_id"`            // Mongo 主键 _id
+	Overhead      bool               `json:"overhead"`       // 是否置顶
+	Order         int64              `json:"order"`          // 置顶顺序
+	ExpireTime    *time.Time         `json:"expire_time"`    // 置顶的到期时间
+	ModifiedCount int64              `json:"modified_count"` // 被修改的文档数
+}
+
+// AdminArticleUpdateResultVo 通过AdminArticleUpdateVo提交之后的返回模型
+type AdminArticleUpdateResultVo struct {
+	Title              string             `json:"title" bson:"title"`                             // 文章标题
+	Id                 primitive.ObjectID `json:"_id" bson:"_id,omitempty"`                       // Mongo 主键 _id
+	Slug               string             `json:"slug" bson:"slug"`                               // 当前的slug
+	ModifiedCount      int64              `json:"modified_count" bson:"modified_count"`           // 被修改的文档数
+	ArchivedCategories []string           `json:"archived_categories" bson:"archived_categories"` // 新关联的分类
+	RemovedCategories  []string           `json:"removed_categories" bson:"removed_categories"`   // 被移出的分类
+}
+
+// AdminArticleSlugBackfillResultVo 为旧文章补齐slug之后的返回模型
+type AdminArticleSlugBackfillResultVo struct {
+	Count int64             `json:"count"` // 补齐的文章数
+	Slugs map[string]string `json:"slugs"` // 文章id -> 生成的slug
+}
+
+// AdminArticleContentStatBackfillResultVo 重新统计文章内容之后的返回模型
+type AdminArticleContentStatBackfillResultVo struct {
+	Count int64 `json:"count"` // 重新统计的文章数
 }
 
 // BaseArticleSetPVResultVo 设置pv之后返回的模型
@@ -46,6 +109,7 @@ type BaseArticleSetPVResultVo struct {
 	ModifiedCount int64  `json:"modified_count" bson:"modified_count"` // The number of documents modified by the operation.
 	UpsertedCount int64  `json:"upserted_count" bson:"upserted_count"` // The number of documents upserted by the operation.
 	Id            string `json:"id" bson:"id"`
+	Counted       bool   `json:"counted" bson:"counted"` // 是否计数，重复的阅读与点赞不计数
 }
 
 // BaseParams 基础参数
@@ -73,6 +137,7 @@ type AdminArticleAddFormResultVo struct {
 type BaseArticleSearchVo struct {
 	SearchText string `json:"search_text" form:"search_text"` // 模糊搜素的内容 允许空格
 	Author     string `json:"author" form:"author"`           // 作者名
+	Render     string `json:"render" form:"render"`           // 为html时额外返回服务端渲染的html与目录
 	BaseParams
 }
 
@@ -82,8 +147,10 @@ type BaseArticleSearchResultVo struct {
 	PageNumber int64                             `json:"page_number"` // 页码
 	PageSize   int64                             `json:"page_size"`   // 页面大小
 	AnsCount   int64                             `json:"ans_count"`   // 结果数量
-	TotalCount int64                             `json:"total_count"` // 总数
-	Msg        string                            `json:"msg"`         // 提示信息
+	TotalCount int64                             `json:"total_count"` // 总数，不包含置顶文章
+	// 第一页最前面的置顶文章数量，置顶文章不参与分页计数
+	OverheadCount int64  `json:"overhead_count"`
+	Msg           string `json:"msg"` // 提示信息
 }
 
 // SingleBaseArticleSearchResultVo 单个模糊搜索返回的结果
@@ -91,6 +158,8 @@ type SingleBaseArticleSearchResultVo struct {
 	Id             primitive.ObjectID `json:"_id" bson:"_id,omitempty"`               // Mongo 主键 _id
 	Title          string             `json:"title" bson:"title"`                     // 文章标题
 	Author         string             `json:"author" bson:"author"`                   // 作者
+	Synopsis       string             `json:"synopsis" bson:"synopsis"`               // 备注
+	Slug           string             `json:"slug" bson:"slug"`                       // 可读的唯一标识
 	PicUrl         string             `json:"pic_url" bson:"pic_url"`                 // 图片的链接
 	Markdown       string             `json:"markdown" bson:"markdown"`               // md内容
 	ArtLength      int64              `json:"art_length" bson:"art_length"`           // 文章长度
@@ -101,7 +170,28 @@ type SingleBaseArticleSearchResultVo struct {
 	Categories     []string           `json:"categories" bson:"categories"`           // 分类
 	CreateTime     time.Time          `json:"create_time" bson:"create_time"`         // 创建时间
 	UpdateTime     time.Time          `json:"update_time" bson:"update_time"`         // 更新时间
+	PublishTime    time.Time          `json:"publish_time" bson:"publish_time"`       // 发布时间
+	DraftFlag      bool               `json:"draft_flag" bson:"draft_flag"`           // 是否为草稿
+	Overhead       bool               `json:"overhead" bson:"overhead"`               // 是否置顶
 	Score          float64            `json:"score" bson:"score"`                     // mongo全文检索评分
+	Html           string             `json:"html,omitempty" bson:"-"`                // 服务端渲染的html，render=html时返回
+	Toc            []utils.TocNode    `json:"toc,omitempty" bson:"-"`                 // 由标题构成的目录，render=html时返回
+	// 内容统计与估算的阅读时长
+	ContentStat po.ArticleContentStat `json:"content_stat" bson:"content_stat"`
+	// 带有高亮标记的标题与命中位置附近的摘要，使用search_text搜索时返回，文本已经过html转义
+	HighlightTitle string   `json:"highlight_title,omitempty" bson:"-"`
+	Snippets       []string `json:"snippets,omitempty" bson:"-"`
+	// 文章所在的系列与上一篇、下一篇，按id查询且文章属于某个系列时返回
+	Series *ArticleSeriesContextVo `json:"series,omitempty" bson:"-"`
+	// 相关文章，按id查询时返回
+	Related []ArticleRelatedVo `json:"related,omitempty" bson:"-"`
+}
+
+// AdminArticleListVo admin权限下按发布状态查看文章的模型
+type AdminArticleListVo struct {
+	Status string `json:"status" form:"status"` // draft/scheduled/published，为空时查看回收站以外的所有文章
+	Author string `json:"author" form:"author"` // 作者名
+	BaseParams
 }
 
 // ArticleSearchByCategoryVo 通过分类搜索文章的模型
@@ -119,3 +209,38 @@ type ArticleDeleteParams struct {
 type ArticleDeleteRes struct {
 	Count int64 `json:"count"`
 }
+
+// AdminArticleTrashResultVo 回收站中的文章列表
+type AdminArticleTrashResultVo struct {
+	Articles   []AdminArticleTrashItemVo `json:"articles"`    // 文章列表
+	PageNumber int64                     `json:"page_number"` // 页码
+	PageSize   int64                     `json:"page_size"`   // 页面大小
+	AnsCount   int64                     `json:"ans_count"`   // 结果数量
+	TotalCount int64                     `json:"total_count"` // 总数
+}
+
+// AdminArticleTrashItemVo 回收站中的单篇文章
+type AdminArticleTrashItemVo struct {
+	Id         primitive.ObjectID `json:"_id" bson:"_id,omitempty"`       // Mongo 主键 _id
+	Title      string             `json:"title" bson:"title"`             // 文章标题
+	Author     string             `json:"author" bson:"author"`           // 作者
+	Categories []string           `json:"categories" bson:"categories"`   // 分类
+	UpdateTime time.Time          `json:"update_time" bson:"update_time"` // 更新时间
+	DeleteTime time.Time          `json:"delete_time" bson:"delete_time"` // 移入回收站的时间
+	PurgeTime  time.Time          `json:"purge_time" bson:"-"`            // 预计彻底删除的时间
+}
+
+// AdminArticlePurgeVo 清理回收站的参数
+type AdminArticlePurgeVo struct {
+	RetentionDays *int `json:"retention_days" form:"retention_days"` // 保留天数，未指定时使用配置，为0时清空回收站
+}
+
+// AdminSearchIndexVo 文章全文索引的状态
+type AdminSearchIndexVo struct {
+	Backend   string `json:"backend"`   // 当前使用的搜索后端 mongo/index
+	Path      string `json:"path"`      // 索引的持久化路径
+	Ready     bool   `json:"ready"`     // 索引是否已经加载
+	Documents int64  `json:"documents"` // 索引中的文章数，包含草稿与定时发布的文章
+	Terms     int64  `json:"terms"`     // 词表的大小
+	Dirty     bool   `json:"dirty"`     // 是否有尚未保存到磁盘的变动
+}
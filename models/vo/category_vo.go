@@ -13,3 +13,44 @@ type ArchiveArticleVo struct {
 	ArticleId    string `json:"article_id"`
 	CategoryName string `json:"category_name"`
 }
+
+// AdminCategoryCreateVo 新建分类
+type AdminCategoryCreateVo struct {
+	Name        string `json:"name" form:"name" binding:"required"`
+	Description string `json:"description" form:"description"`
+	Cover       string `json:"cover" form:"cover"`           // 封面图片的链接
+	SortOrder   int64  `json:"sort_order" form:"sort_order"` // 排序序号，越小越靠前
+}
+
+// AdminCategoryUpdateVo 修改分类，只修改非空的字段，name与原名不同时重命名并同步修改文章中的分类
+type AdminCategoryUpdateVo struct {
+	Name        *string `json:"name" form:"name"`
+	Description *string `json:"description" form:"description"`
+	Cover       *string `json:"cover" form:"cover"`
+	SortOrder   *int64  `json:"sort_order" form:"sort_order"`
+}
+
+// AdminCategoryMergeVo 合并分类
+type AdminCategoryMergeVo struct {
+	From []string `json:"from" form:"from" binding:"required"` // 被合并的分类
+	To   string   `json:"to" form:"to" binding:"required"`     // 合并到的分类，不存在时新建
+}
+
+// AdminCategoryDeleteVo 删除分类
+type AdminCategoryDeleteVo struct {
+	To string `json:"to" form:"to"` // 文章改为归入的分类，为空时只从文章中移除该分类
+}
+
+// AdminCategoryResultVo 重命名、合并或删除分类之后的返回模型
+type AdminCategoryResultVo struct {
+	From          []string `json:"from"`           // 被修改的分类
+	To            string   `json:"to"`             // 文章改为归入的分类
+	ModifiedCount int64    `json:"modified_count"` // 被修改的文章数
+}
+
+// AdminCategoryRepairResultVo 修复分类之后的返回模型
+type AdminCategoryRepairResultVo struct {
+	TotalCount int64    `json:"total_count"` // 修复之后的分类数
+	Fixed      []string `json:"fixed"`       // 文章id或文章数与文章不一致而被修复的分类
+	Created    []string `json:"created"`     // 文章中存在但缺少记录而补建的分类
+}
@@ -0,0 +1,93 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章评论视图模型
+ * @File:  comment_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// BaseCommentAddVo 访客发表评论的模型
+type BaseCommentAddVo struct {
+	Name     string `json:"name" form:"name" binding:"required"`         // 昵称
+	Email    string `json:"email" form:"email" binding:"required,email"` // 邮箱
+	Website  string `json:"website" form:"website"`                      // 网站 http(s)开头
+	Content  string `json:"content" form:"content" binding:"required"`   // 评论内容
+	ParentId string `json:"parent_id" form:"parent_id"`                  // 回复的评论id，为空时为顶层评论
+}
+
+// BaseCommentAddResultVo 发表评论之后的返回模型
+type BaseCommentAddResultVo struct {
+	Id     primitive.ObjectID `json:"_id"`    // Mongo 主键 _id
+	Status string             `json:"status"` // 审核状态，新评论需要等待审核
+}
+
+// BaseCommentVo 对外展示的评论，不包含邮箱与ip
+type BaseCommentVo struct {
+	Id         primitive.ObjectID `json:"_id"`         // Mongo 主键 _id
+	ParentId   primitive.ObjectID `json:"parent_id"`   // 回复的评论
+	ReplyTo    string             `json:"reply_to"`    // 回复的评论的昵称
+	Name       string             `json:"name"`        // 昵称
+	Website    string             `json:"website"`     // 网站
+	Content    string             `json:"content"`     // 评论内容
+	CreateTime time.Time          `json:"create_time"` // 创建时间
+	Replies    []BaseCommentVo    `json:"replies"`     // 楼中楼的回复，按时间正序平铺，只有顶层评论有
+}
+
+// BaseCommentListResultVo 文章评论列表，按顶层评论分页
+type BaseCommentListResultVo struct {
+	Comments   []BaseCommentVo `json:"comments"`    // 顶层评论及其回复
+	PageNumber int64           `json:"page_number"` // 页码
+	PageSize   int64           `json:"page_size"`   // 页大小
+	AnsCount   int64           `json:"ans_count"`   // 本页顶层评论数
+	TotalCount int64           `json:"total_count"` // 顶层评论总数
+	Count      int64           `json:"count"`       // 已通过审核的评论总数，包括回复
+}
+
+// AdminCommentListVo 管理员查看评论的参数
+type AdminCommentListVo struct {
+	Status     string `json:"status" form:"status"`         // pending/approved/rejected/spam，为空时查看全部
+	ArticleId  string `json:"article_id" form:"article_id"` // 只看某一篇文章的评论
+	BaseParams `json:"base_params"`
+}
+
+// AdminCommentVo 管理员视角下的评论
+type AdminCommentVo struct {
+	Id         primitive.ObjectID `json:"_id" bson:"_id"`                 // Mongo 主键 _id
+	ArticleId  primitive.ObjectID `json:"article_id" bson:"article_id"`   // 所属文章
+	ParentId   primitive.ObjectID `json:"parent_id" bson:"parent_id"`     // 回复的评论
+	RootId     primitive.ObjectID `json:"root_id" bson:"root_id"`         // 所在楼层的顶层评论
+	Name       string             `json:"name" bson:"name"`               // 昵称
+	Email      string             `json:"email" bson:"email"`             // 邮箱
+	Website    string             `json:"website" bson:"website"`         // 网站
+	Content    string             `json:"content" bson:"content"`         // 评论内容
+	Status     string             `json:"status" bson:"status"`           // 审核状态
+	Ip         string             `json:"ip" bson:"ip"`                   // 评论者ip
+	UserAgent  string             `json:"user_agent" bson:"user_agent"`   // 评论者UA
+	CreateTime time.Time          `json:"create_time" bson:"create_time"` // 创建时间
+	UpdateTime time.Time          `json:"update_time" bson:"update_time"` // 审核状态的更新时间
+}
+
+// AdminCommentListResultVo 管理员查看评论的结果
+type AdminCommentListResultVo struct {
+	Comments   []AdminCommentVo `json:"comments"`    // 评论，按创建时间倒序
+	PageNumber int64            `json:"page_number"` // 页码
+	PageSize   int64            `json:"page_size"`   // 页大小
+	AnsCount   int64            `json:"ans_count"`   // 本页数量
+	TotalCount int64            `json:"total_count"` // 总数
+}
+
+// AdminCommentResultVo 审核或删除评论之后的返回模型
+type AdminCommentResultVo struct {
+	Id             primitive.ObjectID `json:"_id"`             // 评论id
+	Status         string             `json:"status"`          // 审核状态，删除时为空
+	ModifiedCount  int64              `json:"modified_count"`  // 被修改或删除的评论数，删除时包括所有回复
+	CommentsNumber int64              `json:"comments_number"` // 文章最新的评论数
+}
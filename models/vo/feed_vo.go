@@ -0,0 +1,135 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 订阅源视图模型
+ * 	FOLLOW: https://www.rssboard.org/rss-specification
+ * 	FOLLOW: https://datatracker.ietf.org/doc/html/rfc4287
+ * 	FOLLOW: https://www.jsonfeed.org/version/1.1/
+ * @File:  feed_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "encoding/xml"
+
+// RssFeed RSS 2.0
+type RssFeed struct {
+	XMLName   xml.Name   `xml:"rss"`
+	Version   string     `xml:"version,attr"`
+	XmlnsAtom string     `xml:"xmlns:atom,attr"`
+	XmlnsDc   string     `xml:"xmlns:dc,attr"`
+	XmlnsCont string     `xml:"xmlns:content,attr"`
+	Channel   RssChannel `xml:"channel"`
+}
+
+// RssChannel RSS的频道
+type RssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	Generator     string    `xml:"generator,omitempty"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	AtomLink      AtomLink  `xml:"atom:link"`
+	Items         []RssItem `xml:"item"`
+}
+
+// RssItem RSS的条目
+type RssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	Guid        RssGuid  `xml:"guid"`
+	Description string   `xml:"description"`
+	Content     CData    `xml:"content:encoded"`
+	Creator     string   `xml:"dc:creator,omitempty"`
+	Categories  []string `xml:"category"`
+	PubDate     string   `xml:"pubDate"`
+}
+
+// RssGuid RSS条目的唯一标识
+type RssGuid struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// CData 以CDATA输出的文本，用于html内容
+type CData struct {
+	Text string `xml:",cdata"`
+}
+
+// AtomFeed Atom 1.0
+type AtomFeed struct {
+	XMLName  xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Id       string      `xml:"id"`
+	Updated  string      `xml:"updated"`
+	Links    []AtomLink  `xml:"link"`
+	Entries  []AtomEntry `xml:"entry"`
+}
+
+// AtomLink Atom的链接，RSS中的atom:link也使用它
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomEntry Atom的条目
+type AtomEntry struct {
+	Title      string         `xml:"title"`
+	Id         string         `xml:"id"`
+	Link       AtomLink       `xml:"link"`
+	Published  string         `xml:"published"`
+	Updated    string         `xml:"updated"`
+	Author     AtomPerson     `xml:"author"`
+	Categories []AtomCategory `xml:"category"`
+	Summary    string         `xml:"summary,omitempty"`
+	Content    AtomText       `xml:"content"`
+}
+
+// AtomPerson Atom的作者
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+// AtomCategory Atom的分类
+type AtomCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// AtomText Atom中带类型的文本
+type AtomText struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// JsonFeed JSON Feed 1.1
+type JsonFeed struct {
+	Version     string         `json:"version"`
+	Title       string         `json:"title"`
+	HomePageUrl string         `json:"home_page_url"`
+	FeedUrl     string         `json:"feed_url"`
+	Description string         `json:"description,omitempty"`
+	Items       []JsonFeedItem `json:"items"`
+}
+
+// JsonFeedItem JSON Feed的条目
+type JsonFeedItem struct {
+	Id            string           `json:"id"`
+	Url           string           `json:"url"`
+	Title         string           `json:"title"`
+	ContentHtml   string           `json:"content_html"`
+	Summary       string           `json:"summary,omitempty"`
+	Image         string           `json:"image,omitempty"`
+	DatePublished string           `json:"date_published"`
+	DateModified  string           `json:"date_modified"`
+	Authors       []JsonFeedAuthor `json:"authors,omitempty"`
+	Tags          []string         `json:"tags,omitempty"`
+}
+
+// JsonFeedAuthor JSON Feed的作者
+type JsonFeedAuthor struct {
+	Name string `json:"name"`
+}
@@ -0,0 +1,63 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站搜索的视图模型
+ * @File:  search_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import (
+	"r0Website-server/models/po"
+	"time"
+)
+
+// BaseSearchVo 全站搜索的参数
+type BaseSearchVo struct {
+	Keyword    string   `json:"q" form:"q" binding:"required"` // 关键字
+	Types      []string `json:"type" form:"type"`              // 只返回这些类型的结果 article/album/image/tag，可重复或用逗号分隔，为空时返回所有类型
+	PageNumber int64    `json:"page_number" form:"page_number"`
+	PageSize   int64    `json:"page_size" form:"page_size"`
+}
+
+// SearchItemVo 一条搜索结果
+type SearchItemVo struct {
+	Type           string     `json:"type"` // article/album/image/tag
+	Id             string     `json:"id"`
+	Title          string     `json:"title"`
+	HighlightTitle string     `json:"highlight_title"`    // 带有高亮标记的标题，已经过html转义
+	Summary        string     `json:"summary,omitempty"`  // 文章的备注或图集的描述
+	Snippets       []string   `json:"snippets,omitempty"` // 文章中命中位置附近的摘要
+	Slug           string     `json:"slug,omitempty"`     // 文章的slug
+	Thumb          string     `json:"thumb,omitempty"`    // 文章的图片或图片的缩略图
+	Tags           []string   `json:"tags,omitempty"`     // 文章、图集或图片的标签
+	Source         string     `json:"source,omitempty"`   // 标签的来源 article/image
+	Count          int64      `json:"count,omitempty"`    // 使用该标签的文章数或图片数
+	Time           *time.Time `json:"time,omitempty"`     // 文章的创建时间、图集的更新时间或图片的上传时间
+	Score          float64    `json:"score"`              // 归一化之后的相关度，不同类型之间可以比较
+}
+
+// SearchFacetVo 某一类型的命中数
+type SearchFacetVo struct {
+	Type  string `json:"type"`
+	Count int64  `json:"count"` // 命中的总数，不受类型过滤与分页的影响
+}
+
+// SearchResultVo 全站搜索的结果
+type SearchResultVo struct {
+	Keyword    string          `json:"keyword"`
+	Items      []SearchItemVo  `json:"items"`  // 按相关度倒序
+	Facets     []SearchFacetVo `json:"facets"` // 各类型的命中数
+	PageNumber int64           `json:"page_number"`
+	PageSize   int64           `json:"page_size"`
+	AnsCount   int64           `json:"ans_count"`
+	TotalCount int64           `json:"total_count"` // 参与排序的结果数，每种类型最多取前若干条
+}
+
+// ScoredAlbumVo 带有全文检索评分的图集
+type ScoredAlbumVo struct {
+	po.Album `bson:",inline"`
+	Score    float64 `bson:"score"`
+}
@@ -0,0 +1,66 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章系列视图模型
+ * @File:  series_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"time"
+)
+
+// AdminSeriesAddVo 新建系列的模型
+type AdminSeriesAddVo struct {
+	Title       string   `json:"title" form:"title" binding:"required"` // 系列标题
+	Description string   `json:"description" form:"description"`        // 系列说明
+	ArticleIds  []string `json:"article_ids" form:"article_ids"`        // 按阅读顺序排列的文章id
+}
+
+// AdminSeriesUpdateVo 修改系列的模型，只修改提交了的字段
+type AdminSeriesUpdateVo struct {
+	Title       *string   `json:"title" form:"title"`             // 系列标题
+	Description *string   `json:"description" form:"description"` // 系列说明
+	ArticleIds  *[]string `json:"article_ids" form:"article_ids"` // 按阅读顺序排列的文章id，整体替换
+}
+
+// SeriesEntryVo 系列中的一篇文章
+type SeriesEntryVo struct {
+	Id         string `json:"_id"`                   // 文章id
+	Title      string `json:"title"`                 // 文章标题
+	DraftFlag  bool   `json:"draft_flag,omitempty"`  // 是否为草稿，只在管理员视角下出现
+	DeleteFlag bool   `json:"delete_flag,omitempty"` // 是否在回收站，只在管理员视角下出现
+	Missing    bool   `json:"missing,omitempty"`     // 文章已被彻底删除，只在管理员视角下出现
+}
+
+// AdminSeriesVo 管理员视角下的系列
+type AdminSeriesVo struct {
+	Id          primitive.ObjectID `json:"_id"`         // Mongo 主键 _id
+	Title       string             `json:"title"`       // 系列标题
+	Description string             `json:"description"` // 系列说明
+	Articles    []SeriesEntryVo    `json:"articles"`    // 按阅读顺序排列的文章
+	CreateTime  time.Time          `json:"create_time"` // 创建时间
+	UpdateTime  time.Time          `json:"update_time"` // 更新时间
+}
+
+// AdminSeriesListVo 所有系列
+type AdminSeriesListVo struct {
+	Series     []AdminSeriesVo `json:"series"`      // 系列，按更新时间倒序
+	TotalCount int64           `json:"total_count"` // 总数
+}
+
+// ArticleSeriesContextVo 文章在系列中的位置，只统计对外可见的文章
+type ArticleSeriesContextVo struct {
+	Id          primitive.ObjectID `json:"_id"`         // 系列id
+	Title       string             `json:"title"`       // 系列标题
+	Description string             `json:"description"` // 系列说明
+	Index       int                `json:"index"`       // 当前文章是第几篇，从1开始
+	Total       int                `json:"total"`       // 系列中的文章数
+	Prev        *SeriesEntryVo     `json:"prev"`        // 上一篇，第一篇时为空
+	Next        *SeriesEntryVo     `json:"next"`        // 下一篇，最后一篇时为空
+	Entries     []SeriesEntryVo    `json:"entries"`     // 系列中的所有文章
+}
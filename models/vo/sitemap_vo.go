@@ -0,0 +1,39 @@
+// Package vo
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 站点地图视图模型
+ * 	FOLLOW: https://www.sitemaps.org/protocol.html
+ * @File:  sitemap_vo
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package vo
+
+import "encoding/xml"
+
+// SitemapUrlSet 站点地图
+type SitemapUrlSet struct {
+	XMLName xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 urlset"`
+	Urls    []SitemapUrl `xml:"url"`
+}
+
+// SitemapUrl 站点地图中的一个地址
+type SitemapUrl struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// SitemapIndex 站点地图索引，地址数量超过单个站点地图的上限时使用
+type SitemapIndex struct {
+	XMLName  xml.Name     `xml:"http://www.sitemaps.org/schemas/sitemap/0.9 sitemapindex"`
+	Sitemaps []SitemapRef `xml:"sitemap"`
+}
+
+// SitemapRef 站点地图索引中的一个站点地图
+type SitemapRef struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
@@ -16,6 +16,7 @@ import (
 	"r0Website-server/dao"
 	"r0Website-server/global"
 	"r0Website-server/initialize"
+	"r0Website-server/service"
 	"r0Website-server/utils"
 	"reflect"
 )
@@ -37,6 +38,9 @@ func RegisterComponentSingle(component interface{}, exitFunc func(item *R0IocIte
 	}
 	componentsName := componentsKey.Elem().Name()          // 获取的是实际的类型, 比如 BasicDao
 	componentsType := componentsVal.Elem().Type().String() // 获取的是导入实例的类型，比如 dao.BasicDao
+	if _, exists := R0Ioc[componentsName]; !exists {
+		R0IocOrder = append(R0IocOrder, componentsName)
+	}
 	R0Ioc[componentsName] = &R0IocItem{
 		Name:     componentsName,
 		Type:     componentsType,
@@ -126,6 +130,9 @@ type R0IocItem struct {
 
 var R0Ioc = map[string]*R0IocItem{}
 
+// R0IocOrder 组件的注册顺序，退出时按相反的顺序结束，后注册的组件可能依赖先注册的组件
+var R0IocOrder []string
+
 var R0Route = &struct {
 	AdminCategoryController   *admin.CategoryController
 	AdminArticleController    *admin.ArticleController
@@ -137,6 +144,13 @@ var R0Route = &struct {
 	PicBedImageController     *base.PicBedImageController
 	ImageCategoryController   *base.ImageCategoryController
 	TagController             *base.TagController
+	AdminCommentController    *admin.CommentController
+	BaseCommentController     *base.CommentController
+	FeedController            *base.FeedController
+	SitemapController         *base.SitemapController
+	AdminSeriesController     *admin.SeriesController
+	AdminSearchController     *admin.SearchController
+	BaseSearchController      *base.SearchController
 }{}
 
 // InitR0Ioc 初始化容器
@@ -161,6 +175,12 @@ func InitR0Ioc(configPath string) {
 	RegisterComponentSingle(basicDao, func(item *R0IocItem) {
 		item.Instance.(*dao.BasicDaoMongo).Disconnect()
 	})
+	// 文章计数器需要是单例，退出时写入尚未写入的增量，因此在数据库连接之后注册
+	articleCounter := &service.ArticleCounterService{}
+	Injection(articleCounter)
+	RegisterComponentSingle(articleCounter, func(item *R0IocItem) {
+		item.Instance.(*service.ArticleCounterService).Close()
+	})
 	Injection(InitR0Route()...)
 	for key, value := range R0Ioc {
 		fmt.Println("[R0IOC]\t", key, "\t---->\t", value)
@@ -169,8 +189,8 @@ func InitR0Ioc(configPath string) {
 
 // ExitR0Ioc 退出容器
 func ExitR0Ioc() {
-	for _, value := range R0Ioc {
-		if value.ExitFunc != nil {
+	for i := len(R0IocOrder) - 1; i >= 0; i-- {
+		if value := R0Ioc[R0IocOrder[i]]; value.ExitFunc != nil {
 			value.ExitFunc(value)
 		}
 	}
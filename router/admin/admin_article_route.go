@@ -19,10 +19,49 @@ func InitArticleFileRouter(r *gin.RouterGroup) {
 	group := r.Group("article")
 	{
 		// 不使用 /*id 的匹配是因为不想处理前后的"/"
-		group.POST("", article.ArticleFormWay)    // 通过编辑的方式增加文章 无id自动生成
-		group.POST(":id", article.ArticleFormWay) // 通过编辑的方式增加文章 id是必选的
-		group.DELETE(":id", article.ArticleDelete)
-		group.POST("/upload", article.ArticleFileWay)     // 通过上传文件的方式增加文章 无id自动生成
-		group.POST("/upload/:id", article.ArticleFileWay) // 通过上传文件的方式增加文章 id是必选的
+		group.POST("", article.ArticleFormWay)                                       // 通过编辑的方式增加文章 无id自动生成
+		group.POST(":id", article.ArticleFormWay)                                    // 通过编辑的方式增加文章 id是必选的 已存在时覆盖并留下快照
+		group.PUT(":id", article.ArticleUpdate)                                      // 局部更新文章 保留计数器与创建时间
+		group.PATCH(":id", article.ArticleUpdate)                                    // 同PUT
+		group.DELETE(":id", article.ArticleDelete)                                   // 移入回收站
+		group.GET("trash", article.ArticleTrash)                                     // 回收站列表
+		group.PUT(":id/restore", article.ArticleRestore)                             // 移出回收站
+		group.DELETE("trash/:id", article.ArticlePurge)                              // 彻底删除回收站中的文章
+		group.DELETE("trash", article.ArticlePurgeTrash)                             // 彻底删除超过保留期的文章
+		group.POST(":id/backup", article.ArticleBackup)                              // 手动快照
+		group.GET(":id/revisions", article.ArticleRevisions)                         // 历史版本列表
+		group.GET(":id/revisions/:version", article.ArticleRevision)                 // 某一历史版本
+		group.POST(":id/revisions/:version/restore", article.ArticleRevisionRestore) // 恢复到某一历史版本
+		group.GET(":id/diff", article.ArticleRevisionDiff)                           // 比较两个版本 from/to 为0时表示当前内容
+		group.POST("/upload", article.ArticleFileWay)                                // 通过上传文件的方式增加文章 无id自动生成
+		group.POST("/upload/:id", article.ArticleFileWay)                            // 通过上传文件的方式增加文章 id是必选的
+
+		// 草稿与定时发布
+		group.GET("", article.ArticleList) // 文章列表 status=draft/scheduled/published，为空时查看回收站以外的所有文章
+
+		// 置顶
+		group.PUT(":id/overhead", article.ArticleOverhead) // 置顶或取消置顶 可指定顺序与到期时间
+
+		// 标签
+		group.GET("tags", article.ArticleTags)           // 所有标签及其文章数 包含草稿与定时发布的文章
+		group.PUT("tag/:tag", article.ArticleTagRename)  // 重命名标签 新的标签已存在时等同于合并
+		group.POST("tag/merge", article.ArticleTagMerge) // 合并标签
+
+		// slug
+		group.POST("slug/backfill", article.ArticleSlugBackfill) // 为还没有slug的旧文章生成slug
+
+		// 内容统计
+		group.POST("content-stat/backfill", article.ArticleContentStatBackfill) // 重新统计所有文章的字数、图片、代码行数与阅读时长
+
+		// 导入
+		group.POST("import", article.ArticleImport) // 批量导入zip压缩包中的md文件 解析Hexo/Hugo/Jekyll的front matter
+
+		// 导出
+		group.GET("export", article.ArticleExport) // 导出全站文章为zip images=true时同时导出引用的图床图片 trash=true时包含回收站
+
+		// 统计
+		group.GET("stats", article.ArticleStats)     // 全站每日阅读数与来源、路径、设备的分布 start/end为日期 默认最近30天
+		group.GET(":id/stats", article.ArticleStats) // 单篇文章的统计
+		group.GET("stats/top", article.ArticleTop)   // 最近days天阅读数最多的文章 默认7天
 	}
 }
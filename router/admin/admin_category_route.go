@@ -11,5 +11,10 @@ func InitCategoryFileRouter(r *gin.RouterGroup) {
 	{
 		// 不使用 /*id 的匹配是因为不想处理前后的"/"
 		group.POST("/archive", article.ArchiveArticle)
+		group.POST("", article.Create)         // 新建分类
+		group.PUT("/:name", article.Update)    // 修改描述、封面与排序序号 name变化时重命名
+		group.DELETE("/:name", article.Delete) // 删除分类 to不为空时文章改为归入to
+		group.POST("/merge", article.Merge)    // 合并分类
+		group.POST("/repair", article.Repair)  // 由文章重新统计分类的文章id与文章数
 	}
 }
@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitCommentRouter(r *gin.RouterGroup) {
+	comment := r0Ioc.R0Route.AdminCommentController
+	group := r.Group("comment")
+	{
+		group.GET("", comment.CommentList)               // 评论列表 status=pending/approved/rejected/spam
+		group.PUT(":id/approve", comment.CommentApprove) // 通过审核
+		group.PUT(":id/reject", comment.CommentReject)   // 拒绝
+		group.PUT(":id/spam", comment.CommentSpam)       // 标记为垃圾评论
+		group.DELETE(":id", comment.CommentDelete)       // 删除评论及其回复
+	}
+}
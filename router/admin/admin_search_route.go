@@ -0,0 +1,15 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitSearchRouter(r *gin.RouterGroup) {
+	search := r0Ioc.R0Route.AdminSearchController
+	group := r.Group("search")
+	{
+		group.GET("index", search.SearchIndexStatus)           // 全文索引的状态
+		group.POST("index/rebuild", search.SearchIndexRebuild) // 重新建立全文索引 仅在search.backend为index时可用
+	}
+}
@@ -0,0 +1,18 @@
+package admin
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitSeriesRouter(r *gin.RouterGroup) {
+	series := r0Ioc.R0Route.AdminSeriesController
+	group := r.Group("series")
+	{
+		group.POST("", series.SeriesAdd)         // 新建系列
+		group.GET("", series.SeriesList)         // 所有系列
+		group.GET(":id", series.SeriesDetail)    // 某一系列
+		group.PUT(":id", series.SeriesUpdate)    // 修改系列 article_ids整体替换
+		group.DELETE(":id", series.SeriesDelete) // 删除系列
+	}
+}
@@ -16,12 +16,38 @@ import (
 
 func InitBaseArticleRouter(Router *gin.RouterGroup) {
 	article := r0Ioc.R0Route.BaseArticleController
+	comment := r0Ioc.R0Route.BaseCommentController
 	group := Router.Group("article")
 	{
 		group.GET("", article.ArticleSearch)                   // 模糊搜素
 		group.GET(":id", article.ArticleSearch)                // id精确搜索
-		group.PUT(":id/pv", article.AddPV)                     // 设置PV
-		group.PUT(":id/praise", article.AddPraise)             // 增加一次praise
+		group.PUT(":id/pv", article.AddPV)                     // 设置PV 同一访客在去重时长内只计一次
+		group.PUT(":id/praise", article.AddPraise)             // 增加一次praise 每位访客只计一次
+		group.DELETE(":id/praise", article.RemovePraise)       // 取消praise
 		group.GET("category/:name", article.ArticleInCategory) // 某一分类下的文章
+
+		// 标签
+		group.GET("tags", article.ArticleTags)      // 所有标签及其文章数
+		group.GET("tag/:tag", article.ArticleInTag) // 某一标签下的文章
+
+		// 归档
+		group.GET("archive", article.ArticleArchive)              // 按年、月归档
+		group.GET("archive/:year/:month", article.ArticleInMonth) // 某一月份创建的文章
+
+		// 相关文章
+		group.GET(":id/related", article.ArticleRelated) // 由共同的标签、分类与分词计算的相关文章 size为数量
+
+		// 热门文章
+		group.GET("hot", article.ArticleHot) // 按随时间衰减的阅读、点赞与评论数排序 size为数量
+
+		// slug
+		group.GET("slug/:slug", article.ArticleBySlug) // 通过slug获取文章 旧的slug重定向到当前slug
+
+		// 代码高亮
+		group.GET("highlight.css", article.HighlightCSS) // 高亮的css style=github/monokai/dracula/nord/solarized-light/solarized-dark
+
+		// 评论
+		group.GET(":id/comments", comment.CommentList) // 通过审核的评论
+		group.POST(":id/comments", comment.AddComment) // 发表评论 审核之后展示
 	}
 }
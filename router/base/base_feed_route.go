@@ -0,0 +1,27 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 订阅源路由，挂在根路径下方便阅读器发现
+ * @File:  base_feed_route
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitFeedRouter(Router gin.IRouter) {
+	feed := r0Ioc.R0Route.FeedController
+	{
+		Router.GET("feed.xml", feed.Rss)                  // RSS 2.0
+		Router.GET("atom.xml", feed.Atom)                 // Atom 1.0
+		Router.GET("feed.json", feed.Json)                // JSON Feed 1.1
+		Router.GET("category/:name/feed.xml", feed.Rss)   // 某一分类的RSS
+		Router.GET("category/:name/atom.xml", feed.Atom)  // 某一分类的Atom
+		Router.GET("category/:name/feed.json", feed.Json) // 某一分类的JSON Feed
+	}
+}
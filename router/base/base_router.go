@@ -21,6 +21,7 @@ func InitBaseRouter(Router *gin.RouterGroup) (R gin.IRoutes) {
 		Router.POST("register", userController.Register)
 		InitBaseArticleRouter(Router)
 		InitPicBedRouter(Router) // 添加图床路由
+		InitSearchRouter(Router) // 全站搜索
 	}
 	return Router
 }
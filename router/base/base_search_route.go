@@ -0,0 +1,22 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站搜索路由
+ * @File:  base_search_route
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitSearchRouter(Router *gin.RouterGroup) {
+	search := r0Ioc.R0Route.BaseSearchController
+	{
+		Router.GET("search", search.Search) // 同时搜索文章、图集、图片与标签 q为关键字 type过滤类型
+	}
+}
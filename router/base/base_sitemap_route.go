@@ -0,0 +1,24 @@
+// Package base
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 站点地图与robots路由，挂在根路径下
+ * @File:  base_sitemap_route
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package base
+
+import (
+	"github.com/gin-gonic/gin"
+	"r0Website-server/r0Ioc"
+)
+
+func InitSitemapRouter(Router gin.IRouter) {
+	sitemap := r0Ioc.R0Route.SitemapController
+	{
+		Router.GET("sitemap.xml", sitemap.Sitemap)       // 站点地图 超过50000个地址时为索引
+		Router.GET("sitemap/:page", sitemap.SitemapPage) // 索引中的站点地图 /sitemap/1.xml
+		Router.GET("robots.txt", sitemap.Robots)         // robots
+	}
+}
@@ -15,6 +15,8 @@ import (
 	"r0Website-server/r0Ioc"
 	"r0Website-server/router/admin"
 	"r0Website-server/router/base"
+	"r0Website-server/service"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -35,11 +37,36 @@ func Routers() *gin.Engine {
 		global.Logger.Error("AlbumService 未初始化，跳过默认图片分类初始化")
 	}
 
+	// 定期清理回收站
+	if articleService := r0Ioc.R0Route.AdminArticleController.ArticleService; articleService != nil {
+		go articleService.PurgeTrashPeriodically(time.Hour)
+		// 定期重新计算热门文章
+		go articleService.RefreshHotArticlesPeriodically(service.HotRefreshInterval())
+		// 使用进程内的全文索引时提前加载，避免第一次搜索时等待
+		if service.SearchBackend() == service.SearchBackendIndex {
+			go func() {
+				if err := articleService.InitSearchIndex(); err != nil {
+					global.Logger.Errorf("初始化文章索引失败: %v", err)
+				}
+			}()
+		}
+	}
+
 	engine := gin.Default()
+	// 只采用可信代理转发的X-Forwarded-For，否则访客可以伪造ClientIP绕过阅读与点赞的去重
+	if err := engine.SetTrustedProxies(global.Config.System.TrustedProxies); err != nil {
+		global.Logger.Errorf("可信代理的配置有误，不信任任何代理: %v", err)
+		_ = engine.SetTrustedProxies(nil)
+	}
 	engine.MaxMultipartMemory = 64 << 20 // 允许更大的 multipart 表单
 	engine.Use(middleware.Logger())
 	engine.Use(middleware.Cors())
 
+	// 订阅源
+	base.InitFeedRouter(engine)
+	// 站点地图与robots
+	base.InitSitemapRouter(engine)
+
 	root := engine.Group("api")
 	{
 		baseGroup := root.Group("base")
@@ -55,6 +82,9 @@ func Routers() *gin.Engine {
 			// TODO 需要鉴权的admin接口
 			admin.InitArticleFileRouter(adminGroup)
 			admin.InitCategoryFileRouter(adminGroup)
+			admin.InitCommentRouter(adminGroup)
+			admin.InitSeriesRouter(adminGroup)
+			admin.InitSearchRouter(adminGroup)
 		}
 	}
 	return engine
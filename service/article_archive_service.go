@@ -0,0 +1,30 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章按创建时间归档
+ * @File:  article_archive_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"r0Website-server/models/vo"
+)
+
+// ArticleArchive 对外可见的文章按年、月归档
+func (article *ArticleService) ArticleArchive() (*vo.ArticleArchiveVo, error) {
+	return article.ArticleDao.ArticleArchive()
+}
+
+// ArticleInMonth 某一月份创建的文章
+func (article *ArticleService) ArticleInMonth(
+	params vo.ArticleSearchByMonthVo,
+) (*vo.BaseArticleSearchResultVo, error) {
+	if params.Year <= 0 || params.Month < 1 || params.Month > 12 {
+		return nil, errors.New("ArticleInMonth: 年份或月份不合法")
+	}
+	return article.ArticleDao.ArticleInMonth(params)
+}
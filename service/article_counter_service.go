@@ -0,0 +1,309 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章阅读数与点赞数的计数器，按访客去重，增量在内存中累积之后定期批量写入数据库
+ * 	计入的阅读同时按天累积到文章的统计中
+ * @File:  article_counter_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"r0Website-server/dao"
+	"r0Website-server/global"
+	"r0Website-server/models/bo"
+	"r0Website-server/utils"
+	"sync"
+	"time"
+)
+
+// ArticleCounterService 计数器需要作为单例注册到容器中，退出时调用Close写入尚未写入的增量
+// 未注册时每个注入点各有一个实例，同样可以工作，但去重只在实例内生效
+type ArticleCounterService struct {
+	ArticleDao       *dao.ArticleDao       `R0Ioc:"true"`
+	ArticlePraiseDao *dao.ArticlePraiseDao `R0Ioc:"true"`
+	ArticleStatDao   *dao.ArticleStatDao   `R0Ioc:"true"`
+
+	once         sync.Once
+	mu           sync.Mutex
+	views        map[string]time.Time     // 文章id与访客 -> 去重的到期时间
+	ipVisitors   map[string]*visitorQuota // 计数类型、文章id与IP -> 去重时长内计入的访客数
+	pending      map[primitive.ObjectID]*bo.ArticleCounterDelta
+	pendingStats map[bo.ArticleStatKey]*bo.ArticleStatDelta
+	statSources  map[bo.ArticleStatKey]map[string]bool // 分桶中已经记录过的来源，用于限制来源数
+	stop         chan struct{}
+	done         chan struct{}
+	closed       bool
+}
+
+// ViewDedupWindow 同一访客重复阅读不计数的时长
+func ViewDedupWindow() time.Duration {
+	if global.Config != nil && global.Config.Article.ViewDedupMinutes > 0 {
+		return time.Duration(global.Config.Article.ViewDedupMinutes) * time.Minute
+	}
+	return 30 * time.Minute
+}
+
+// VisitorsPerIp 去重时长内同一IP对一篇文章最多计入的访客数
+func VisitorsPerIp() int {
+	if global.Config != nil && global.Config.Article.VisitorsPerIp > 0 {
+		return global.Config.Article.VisitorsPerIp
+	}
+	return 5
+}
+
+// visitorQuota 一个IP在去重时长内已经计入的访客数
+type visitorQuota struct {
+	count  int
+	expire time.Time
+}
+
+// CounterFlushInterval 增量写入数据库的间隔
+func CounterFlushInterval() time.Duration {
+	if global.Config != nil && global.Config.Article.CounterFlushSeconds > 0 {
+		return time.Duration(global.Config.Article.CounterFlushSeconds) * time.Second
+	}
+	return 10 * time.Second
+}
+
+// visitorFingerprint 访客指纹，由IP、UA与客户端提供的令牌共同决定，只保存摘要
+// 令牌只用于区分同一IP与UA下的不同访客，更换令牌能得到的新指纹受VisitorsPerIp限制
+func visitorFingerprint(visit bo.ArticleVisit) string {
+	source := "ip:" + visit.Ip + "|ua:" + visit.UserAgent + "|token:" + visit.Token
+	sum := sha256.Sum256([]byte(source))
+	return hex.EncodeToString(sum[:16])
+}
+
+// start 第一次使用时初始化并启动定期写入
+func (cs *ArticleCounterService) start() {
+	cs.once.Do(func() {
+		cs.mu.Lock()
+		cs.views = map[string]time.Time{}
+		cs.ipVisitors = map[string]*visitorQuota{}
+		cs.pending = map[primitive.ObjectID]*bo.ArticleCounterDelta{}
+		cs.pendingStats = map[bo.ArticleStatKey]*bo.ArticleStatDelta{}
+		cs.statSources = map[bo.ArticleStatKey]map[string]bool{}
+		cs.stop = make(chan struct{})
+		cs.done = make(chan struct{})
+		cs.mu.Unlock()
+		go cs.flushPeriodically()
+	})
+}
+
+// View 记录一次阅读，同一访客在去重时长内重复阅读或同一IP的访客数超过限制时返回false
+// 不计数的阅读也不进入统计；slug用于识别阅读的前台路径
+func (cs *ArticleCounterService) View(id primitive.ObjectID, slug string, visitor string, visit bo.ArticleVisit) bool {
+	cs.start()
+	key := id.Hex() + ":" + visitor
+	now := time.Now()
+	referrer, page := visitReferrer(visit), visitPage(visit.Page, id.Hex(), slug)
+	device, browser := utils.ParseUserAgent(visit.UserAgent)
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if expire, ok := cs.views[key]; ok && now.Before(expire) {
+		return false
+	}
+	if !cs.allowVisitor("view:"+id.Hex()+":"+visit.Ip, now) {
+		return false
+	}
+	cs.views[key] = now.Add(ViewDedupWindow())
+	cs.delta(id).Reads++
+	statKey := bo.ArticleStatKey{ArticleId: id, Day: now.Format("2006-01-02")}
+	stat, ok := cs.pendingStats[statKey]
+	if !ok {
+		stat = &bo.ArticleStatDelta{
+			Referrers: map[string]int64{}, Paths: map[string]int64{},
+			Devices: map[string]int64{}, Browsers: map[string]int64{},
+		}
+		cs.pendingStats[statKey] = stat
+	}
+	stat.Views++
+	// 来源由客户端提供，超过上限之后新的来源计入other，避免统计文档的字段无限增长
+	sources, ok := cs.statSources[statKey]
+	if !ok {
+		sources = map[string]bool{}
+		cs.statSources[statKey] = sources
+	}
+	if !sources[referrer] {
+		if len(sources) >= articleStatsMaxReferrers {
+			referrer = statOther
+		} else {
+			sources[referrer] = true
+		}
+	}
+	stat.Referrers[referrer]++
+	stat.Devices[device]++
+	stat.Browsers[browser]++
+	if page != "" {
+		stat.Paths[page]++
+	}
+	return true
+}
+
+// Praise 点赞，每位访客对一篇文章只计一次，已经点过赞或同一IP点赞的访客数超过限制时返回false
+func (cs *ArticleCounterService) Praise(id primitive.ObjectID, visitor string, ip string) (bool, error) {
+	cs.start()
+	cs.mu.Lock()
+	allowed := cs.allowVisitor("praise:"+id.Hex()+":"+ip, time.Now())
+	cs.mu.Unlock()
+	if !allowed {
+		return false, nil
+	}
+	added, err := cs.ArticlePraiseDao.AddPraise(id, visitor)
+	if err != nil || !added {
+		return false, err
+	}
+	cs.mu.Lock()
+	cs.delta(id).Praises++
+	cs.mu.Unlock()
+	return true, nil
+}
+
+// Unpraise 取消点赞，访客没有点过赞时返回false
+func (cs *ArticleCounterService) Unpraise(id primitive.ObjectID, visitor string) (bool, error) {
+	cs.start()
+	removed, err := cs.ArticlePraiseDao.RemovePraise(id, visitor)
+	if err != nil || !removed {
+		return false, err
+	}
+	cs.mu.Lock()
+	cs.delta(id).Praises--
+	cs.mu.Unlock()
+	return true, nil
+}
+
+// allowVisitor 同一IP在去重时长内计入的访客数未超过限制时计入一位并返回true，调用方需要持有锁
+// 限制的是不同访客，同一访客的重复计数由调用方去重；点赞被拒绝时已经计入的名额不退回
+func (cs *ArticleCounterService) allowVisitor(key string, now time.Time) bool {
+	quota, ok := cs.ipVisitors[key]
+	if !ok || !now.Before(quota.expire) {
+		quota = &visitorQuota{expire: now.Add(ViewDedupWindow())}
+		cs.ipVisitors[key] = quota
+	}
+	if quota.count >= VisitorsPerIp() {
+		return false
+	}
+	quota.count++
+	return true
+}
+
+// delta 文章的待写入增量，调用方需要持有锁
+func (cs *ArticleCounterService) delta(id primitive.ObjectID) *bo.ArticleCounterDelta {
+	delta, ok := cs.pending[id]
+	if !ok {
+		delta = &bo.ArticleCounterDelta{}
+		cs.pending[id] = delta
+	}
+	return delta
+}
+
+// Flush 将累积的增量写入数据库，写入失败的增量会保留到下一次，已经写入的不会重复写入
+func (cs *ArticleCounterService) Flush() error {
+	cs.start()
+	cs.mu.Lock()
+	pending, pendingStats := cs.pending, cs.pendingStats
+	cs.pending = map[primitive.ObjectID]*bo.ArticleCounterDelta{}
+	cs.pendingStats = map[bo.ArticleStatKey]*bo.ArticleStatDelta{}
+	cs.mu.Unlock()
+	var result error
+	if len(pending) > 0 {
+		if failed, err := cs.ArticleDao.IncArticleCounters(pending); err != nil {
+			cs.mu.Lock()
+			for id, val := range failed {
+				delta := cs.delta(id)
+				delta.Reads += val.Reads
+				delta.Praises += val.Praises
+			}
+			cs.mu.Unlock()
+			result = err
+		}
+	}
+	if len(pendingStats) > 0 {
+		if failed, err := cs.ArticleStatDao.IncArticleStats(pendingStats); err != nil {
+			cs.mu.Lock()
+			for key, val := range failed {
+				if stat, ok := cs.pendingStats[key]; ok {
+					mergeArticleStatDelta(stat, val)
+				} else {
+					cs.pendingStats[key] = val
+				}
+			}
+			cs.mu.Unlock()
+			result = err
+		}
+	}
+	return result
+}
+
+// mergeArticleStatDelta 将from的增量合并到to
+func mergeArticleStatDelta(to, from *bo.ArticleStatDelta) {
+	to.Views += from.Views
+	for _, pair := range [][2]map[string]int64{
+		{to.Referrers, from.Referrers}, {to.Paths, from.Paths}, {to.Devices, from.Devices}, {to.Browsers, from.Browsers},
+	} {
+		for name, count := range pair[1] {
+			pair[0][name] += count
+		}
+	}
+}
+
+// Close 停止定期写入并写入剩余的增量，容器退出时调用
+func (cs *ArticleCounterService) Close() {
+	cs.mu.Lock()
+	started := cs.stop != nil && !cs.closed
+	cs.closed = true
+	cs.mu.Unlock()
+	if started {
+		close(cs.stop)
+		<-cs.done
+	}
+}
+
+// flushPeriodically 定期写入增量并清理过期的去重记录，阻塞运行直到Close
+func (cs *ArticleCounterService) flushPeriodically() {
+	defer close(cs.done)
+	ticker := time.NewTicker(CounterFlushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := cs.Flush(); err != nil {
+				global.Logger.Errorf("写入文章计数失败: %v", err)
+			}
+			cs.pruneViews()
+		case <-cs.stop:
+			if err := cs.Flush(); err != nil {
+				global.Logger.Errorf("写入文章计数失败: %v", err)
+			}
+			return
+		}
+	}
+}
+
+// pruneViews 清理已经过期的去重记录、IP的访客数与之前各天分桶的来源
+func (cs *ArticleCounterService) pruneViews() {
+	now := time.Now()
+	today := now.Format("2006-01-02")
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	for key, expire := range cs.views {
+		if !now.Before(expire) {
+			delete(cs.views, key)
+		}
+	}
+	for key, quota := range cs.ipVisitors {
+		if !now.Before(quota.expire) {
+			delete(cs.ipVisitors, key)
+		}
+	}
+	for key := range cs.statSources {
+		if key.Day != today {
+			delete(cs.statSources, key)
+		}
+	}
+}
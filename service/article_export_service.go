@@ -0,0 +1,215 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站导出，将文章写为带front matter的md文件，与分类、manifest以及可选的图床图片一起打包为zip
+ * 	导出的md文件可以通过批量导入重新导入
+ * @File:  article_export_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"r0Website-server/global"
+	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"strconv"
+	"time"
+)
+
+const (
+	// articleExportFormatVersion 导出格式的版本，导出包的结构变化时加一
+	articleExportFormatVersion = 1
+	// articleExportImageBatchSize 每次查询图片时的地址数
+	articleExportImageBatchSize = 200
+)
+
+// articleExportClient 下载图床图片使用的客户端
+var articleExportClient = &http.Client{Timeout: 2 * time.Minute}
+
+// ArticleExport 导出全站文章，边查询边写入w，不会在内存中保留整个压缩包
+// 导出包的结构：posts/*.md、categories.json、images/*、manifest.json
+func (article *ArticleService) ArticleExport(params vo.AdminArticleExportVo, w io.Writer) error {
+	archive := zip.NewWriter(w)
+	manifest := vo.ArticleExportManifestVo{
+		FormatVersion: articleExportFormatVersion,
+		ExportedAt:    time.Now(),
+		Articles:      []vo.ArticleExportItemVo{},
+		Images:        []vo.ArticleExportImageVo{},
+	}
+	files := map[string]bool{}
+	imageUrls := make([]string, 0)
+	seenUrls := map[string]bool{}
+	err := article.ArticleDao.EachArticle(params.Trash, func(val *po.Article) error {
+		name := articleExportFileName(val, files)
+		content, err := utils.RenderFrontMatter(articleExportFrontMatter(val), val.Markdown)
+		if err != nil {
+			return err
+		}
+		writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate, Modified: val.UpdateTime})
+		if err != nil {
+			return err
+		}
+		if _, err = io.WriteString(writer, content); err != nil {
+			return err
+		}
+		manifest.Articles = append(manifest.Articles, vo.ArticleExportItemVo{
+			Id: val.Id.Hex(), File: name, Title: val.Title, Slug: val.Slug,
+			Draft: val.DraftFlag, Deleted: val.DeleteFlag,
+		})
+		if params.Images {
+			for _, imageUrl := range append(utils.MarkdownImageUrls(val.Markdown), val.PicUrl) {
+				if imageUrl != "" && !seenUrls[imageUrl] {
+					seenUrls[imageUrl] = true
+					imageUrls = append(imageUrls, imageUrl)
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	categories, err := article.CategoryDao.AllCategories()
+	if err != nil {
+		return err
+	}
+	if err = writeExportJson(archive, "categories.json", categories.Categories); err != nil {
+		return err
+	}
+	manifest.Categories = categories.TotalCount
+	if params.Images {
+		if manifest.Images, err = article.exportArticleImages(archive, imageUrls); err != nil {
+			return err
+		}
+	}
+	if err = writeExportJson(archive, "manifest.json", manifest); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// exportArticleImages 下载文章引用的图床图片写入导出包，图床以外的图片不会导出
+// 单张图片下载失败只记录在manifest中，不影响整个导出
+func (article *ArticleService) exportArticleImages(archive *zip.Writer, urls []string) ([]vo.ArticleExportImageVo, error) {
+	result := []vo.ArticleExportImageVo{}
+	seen := map[string]bool{}
+	for start := 0; start < len(urls); start += articleExportImageBatchSize {
+		end := start + articleExportImageBatchSize
+		if end > len(urls) {
+			end = len(urls)
+		}
+		images, err := article.ImageDao.FindImagesByUrls(urls[start:end])
+		if err != nil {
+			return nil, err
+		}
+		for _, image := range images {
+			// 原图与缩略图可能都被引用，只导出一次原图
+			if seen[image.ID.Hex()] {
+				continue
+			}
+			seen[image.ID.Hex()] = true
+			item := vo.ArticleExportImageVo{
+				Id: image.ID.Hex(), Name: image.Name, Url: image.CosURL, ThumbUrl: image.ThumbURL,
+			}
+			name := "images/" + image.ID.Hex()
+			if parsed, err := url.Parse(image.CosURL); err == nil {
+				name += path.Ext(parsed.Path)
+			}
+			if err := exportImage(archive, name, image); err != nil {
+				global.Logger.Warnf("导出图片%s失败: %v", image.CosURL, err)
+				item.Error = err.Error()
+			} else {
+				item.File = name
+			}
+			result = append(result, item)
+		}
+	}
+	return result, nil
+}
+
+// exportImage 下载一张图片写入导出包，写入途中失败时包中会留下不完整的文件
+func exportImage(archive *zip.Writer, name string, image *po.Image) error {
+	resp, err := articleExportClient.Get(image.CosURL)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("exportImage: 下载失败 " + strconv.Itoa(resp.StatusCode))
+	}
+	// 图片本身已经压缩过，直接存储
+	writer, err := archive.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Store, Modified: image.UploadedAt})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, resp.Body)
+	return err
+}
+
+// articleExportFileName 文章在导出包中的路径，优先使用slug，重名时追加id
+func articleExportFileName(val *po.Article, files map[string]bool) string {
+	base := val.Slug
+	if base == "" {
+		base = val.Id.Hex()
+	}
+	name := "posts/" + base + ".md"
+	if files[name] {
+		name = "posts/" + base + "-" + val.Id.Hex() + ".md"
+	}
+	files[name] = true
+	return name
+}
+
+// articleExportFrontMatter 文章对应的front matter
+func articleExportFrontMatter(val *po.Article) bo.ArticleFrontMatter {
+	tags, categories := val.Tags, val.Categories
+	if tags == nil {
+		tags = []string{}
+	}
+	if categories == nil {
+		categories = []string{}
+	}
+	return bo.ArticleFrontMatter{
+		Title:       val.Title,
+		Slug:        val.Slug,
+		Author:      val.Author,
+		Date:        val.CreateTime,
+		Updated:     val.UpdateTime,
+		PublishDate: val.PublishTime,
+		Tags:        tags,
+		Categories:  categories,
+		Draft:       val.DraftFlag,
+		Description: val.Synopsis,
+		Cover:       val.PicUrl,
+		Overhead:    val.Overhead,
+		Deleted:     val.DeleteFlag,
+		Reads:       val.ReadsNumber,
+		Comments:    val.CommentsNumber,
+		Praises:     val.PraiseNumber,
+	}
+}
+
+// writeExportJson 将value格式化为json写入导出包
+func writeExportJson(archive *zip.Writer, name string, value interface{}) error {
+	content, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return err
+	}
+	writer, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
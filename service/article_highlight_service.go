@@ -0,0 +1,28 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 搜索结果的高亮与摘要
+ * @File:  article_highlight_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+)
+
+// searchSnippetCount 每篇文章最多返回的摘要段数
+const searchSnippetCount = 3
+
+// highlightArticles 为搜索命中的文章填充高亮的标题与摘要，需要文章带有md内容
+func highlightArticles(articles []vo.SingleBaseArticleSearchResultVo, searchText string) {
+	terms := utils.SearchTerms(searchText)
+	for index := range articles {
+		current := &articles[index]
+		current.HighlightTitle = utils.HighlightText(current.Title, terms)
+		current.Snippets = utils.HighlightSnippets(utils.MarkdownPlainText(current.Markdown), terms, searchSnippetCount)
+	}
+}
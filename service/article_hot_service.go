@@ -0,0 +1,164 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 热门文章，按Hacker News的方式计算随时间衰减的热度：(阅读+点赞+评论的加权和) / (发布后的小时数+2)^gravity
+ * 	热度在后台定期重新计算并缓存，文章变动时失效
+ * @File:  article_hot_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"math"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// hotMaxSize 单次最多返回的热门文章数，缓存也按这个数量保存
+	hotMaxSize = 50
+	// 各项计数的权重，点赞与评论比阅读更能说明文章受欢迎
+	hotReadWeight    = 1
+	hotPraiseWeight  = 5
+	hotCommentWeight = 10
+)
+
+// hotArticleCache 按热度排好序的文章，由后台定期刷新
+// generation在每次失效时递增，开始计算之后缓存被失效时不写回计算结果
+var hotArticleCache = struct {
+	sync.Mutex
+	articles   []vo.ArticleHotVo
+	builtAt    time.Time
+	generation uint64
+}{}
+
+// invalidateHotArticles 文章新增、修改、删除之后调用，下一次查询时重新计算
+func invalidateHotArticles() {
+	hotArticleCache.Lock()
+	defer hotArticleCache.Unlock()
+	hotArticleCache.articles = nil
+	hotArticleCache.generation++
+}
+
+// HotGravity 热度的时间衰减指数
+func HotGravity() float64 {
+	if global.Config != nil && global.Config.Article.HotGravity > 0 {
+		return global.Config.Article.HotGravity
+	}
+	return 1.8
+}
+
+// HotRefreshInterval 热度重新计算的间隔
+func HotRefreshInterval() time.Duration {
+	if global.Config != nil && global.Config.Article.HotRefreshMinutes > 0 {
+		return time.Duration(global.Config.Article.HotRefreshMinutes) * time.Minute
+	}
+	return 10 * time.Minute
+}
+
+// ArticleHot 热度最高的若干篇对外可见的文章
+func (article *ArticleService) ArticleHot(params vo.BaseArticleHotVo) (*vo.ArticleHotResultVo, error) {
+	size := params.Size
+	if size <= 0 {
+		size = 10
+	}
+	if size > hotMaxSize {
+		size = hotMaxSize
+	}
+	hotArticleCache.Lock()
+	articles, builtAt := hotArticleCache.articles, hotArticleCache.builtAt
+	hotArticleCache.Unlock()
+	if articles == nil {
+		var err error
+		if articles, builtAt, err = article.RefreshHotArticles(); err != nil {
+			return nil, err
+		}
+	}
+	if len(articles) > size {
+		articles = articles[:size]
+	}
+	// 缓存中的切片是共享的，返回副本
+	return &vo.ArticleHotResultVo{
+		Articles: append([]vo.ArticleHotVo{}, articles...), UpdatedAt: builtAt,
+	}, nil
+}
+
+// RefreshHotArticles 重新计算所有对外可见文章的热度并更新缓存
+// 计算期间缓存被失效时，读取到的文章可能已经过时，结果只返回给调用方
+func (article *ArticleService) RefreshHotArticles() ([]vo.ArticleHotVo, time.Time, error) {
+	hotArticleCache.Lock()
+	generation := hotArticleCache.generation
+	hotArticleCache.Unlock()
+	articles, err := article.ArticleDao.ArticlesForHot()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now := time.Now()
+	ranked := rankHotArticles(articles, now, HotGravity())
+	hotArticleCache.Lock()
+	if hotArticleCache.generation == generation {
+		hotArticleCache.articles, hotArticleCache.builtAt = ranked, now
+	}
+	hotArticleCache.Unlock()
+	return ranked, now, nil
+}
+
+// RefreshHotArticlesPeriodically 定期重新计算热度，阻塞运行，需要在单独的goroutine中调用
+func (article *ArticleService) RefreshHotArticlesPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if _, _, err := article.RefreshHotArticles(); err != nil {
+			global.Logger.Errorf("计算热门文章失败: %v", err)
+		}
+		<-ticker.C
+	}
+}
+
+// rankHotArticles 按热度倒序排列，热度相同时较新的文章靠前，只保留前hotMaxSize篇
+func rankHotArticles(articles []po.Article, now time.Time, gravity float64) []vo.ArticleHotVo {
+	ranked := make([]vo.ArticleHotVo, 0, len(articles))
+	for _, val := range articles {
+		// 定时发布的文章从发布时间开始计算
+		published := val.CreateTime
+		if !val.PublishTime.IsZero() {
+			published = val.PublishTime
+		}
+		hours := now.Sub(published).Hours()
+		if hours < 0 {
+			hours = 0
+		}
+		points := float64(hotReadWeight*val.ReadsNumber + hotPraiseWeight*val.PraiseNumber +
+			hotCommentWeight*val.CommentsNumber)
+		score := points / math.Pow(hours+2, gravity)
+		ranked = append(ranked, vo.ArticleHotVo{
+			Id:             val.Id.Hex(),
+			Title:          val.Title,
+			Slug:           val.Slug,
+			Synopsis:       val.Synopsis,
+			PicUrl:         val.PicUrl,
+			Tags:           val.Tags,
+			ReadsNumber:    val.ReadsNumber,
+			PraiseNumber:   val.PraiseNumber,
+			CommentsNumber: val.CommentsNumber,
+			CreateTime:     val.CreateTime.Local(),
+			Score:          score,
+		})
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		return ranked[i].CreateTime.After(ranked[j].CreateTime)
+	})
+	if len(ranked) > hotMaxSize {
+		ranked = ranked[:hotMaxSize]
+	}
+	return ranked
+}
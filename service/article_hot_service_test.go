@@ -0,0 +1,89 @@
+package service
+
+import (
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"r0Website-server/models/po"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRankHotArticles(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	article := func(title string, age time.Duration, reads, praises, comments int64) po.Article {
+		return po.Article{
+			Id: primitive.NewObjectID(), Title: title, CreateTime: now.Add(-age),
+			ReadsNumber: reads, PraiseNumber: praises, CommentsNumber: comments,
+		}
+	}
+	cases := []struct {
+		name     string
+		articles []po.Article
+		gravity  float64
+		want     []string
+	}{
+		{
+			name: "weighted counts",
+			articles: []po.Article{
+				article("reads", time.Hour, 10, 0, 0),
+				article("praises", time.Hour, 0, 3, 0),
+				article("comments", time.Hour, 0, 0, 2),
+			},
+			gravity: 1.8,
+			want:    []string{"comments", "praises", "reads"},
+		},
+		{
+			name: "high gravity sinks older articles",
+			articles: []po.Article{
+				article("old", 100*time.Hour, 1000, 0, 0),
+				article("new", time.Hour, 50, 0, 0),
+			},
+			gravity: 1.8,
+			want:    []string{"new", "old"},
+		},
+		{
+			name: "low gravity keeps popular older articles",
+			articles: []po.Article{
+				article("old", 100*time.Hour, 1000, 0, 0),
+				article("new", time.Hour, 50, 0, 0),
+			},
+			gravity: 0.1,
+			want:    []string{"old", "new"},
+		},
+		{
+			name: "equal scores put newer articles first",
+			articles: []po.Article{
+				article("older", 3*time.Hour, 0, 0, 0),
+				article("newer", 2*time.Hour, 0, 0, 0),
+				article("newest", time.Hour, 0, 0, 0),
+			},
+			gravity: 1.8,
+			want:    []string{"newest", "newer", "older"},
+		},
+		{
+			name: "scheduled articles age from publish time",
+			articles: []po.Article{
+				func() po.Article {
+					val := article("scheduled", 100*time.Hour, 100, 0, 0)
+					val.PublishTime = now.Add(-time.Hour)
+					return val
+				}(),
+				article("plain", 50*time.Hour, 100, 0, 0),
+			},
+			gravity: 1.8,
+			want:    []string{"scheduled", "plain"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ranked := rankHotArticles(c.articles, now, c.gravity)
+			got := make([]string, 0, len(ranked))
+			for _, val := range ranked {
+				got = append(got, val.Title)
+			}
+			if !reflect.DeepEqual(got, c.want) {
+				t.Errorf("rankHotArticles() = %v, want %v", got, c.want)
+			}
+		})
+	}
+}
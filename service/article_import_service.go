@@ -0,0 +1,182 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 从Hexo/Hugo/Jekyll导入文章，解析front matter，支持zip批量导入
+ * @File:  article_import_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"io/ioutil"
+	"path"
+	"r0Website-server/global"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	// articleImportMaxFiles 一个压缩包中最多导入的md文件数
+	articleImportMaxFiles = 1000
+	// articleImportMaxFileSize 单个md文件解压之后的大小上限
+	articleImportMaxFileSize = 8 << 20
+)
+
+// jekyllPostName Jekyll的文章文件名 YYYY-MM-DD-title
+var jekyllPostName = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-(.+)$`)
+
+// ArticleImport 批量导入zip压缩包中的md文件，每个文件创建一篇新文章
+// 单个文件失败不影响其他文件，结果中逐个记录
+func (article *ArticleService) ArticleImport(params vo.AdminArticleImportVo) (*vo.AdminArticleImportResultVo, error) {
+	open, err := params.File.Open()
+	if err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	defer open.Close()
+	reader, err := zip.NewReader(open, params.File.Size)
+	if err != nil {
+		return nil, errors.New("ArticleImport: 无法识别的zip文件 " + err.Error())
+	}
+	files := make([]*zip.File, 0)
+	for _, file := range reader.File {
+		if articleImportable(file) {
+			files = append(files, file)
+		}
+	}
+	if len(files) > articleImportMaxFiles {
+		return nil, errors.New("ArticleImport: 压缩包中的md文件过多")
+	}
+	result := &vo.AdminArticleImportResultVo{
+		Total: int64(len(files)), Results: make([]vo.AdminArticleImportItemVo, 0, len(files)),
+	}
+	for _, file := range files {
+		item := vo.AdminArticleImportItemVo{File: file.Name}
+		if err := article.importArticleFile(file, params.Author, &item); err != nil {
+			item.Error = err.Error()
+			result.Failed++
+		} else {
+			result.Succeeded++
+		}
+		result.Results = append(result.Results, item)
+	}
+	return result, nil
+}
+
+// importArticleFile 导入压缩包中的一个md文件
+func (article *ArticleService) importArticleFile(file *zip.File, author string, item *vo.AdminArticleImportItemVo) error {
+	if file.UncompressedSize64 > articleImportMaxFileSize {
+		return errors.New("文件过大")
+	}
+	open, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer open.Close()
+	// 不完全相信压缩包中记录的大小
+	content, err := ioutil.ReadAll(io.LimitReader(open, articleImportMaxFileSize+1))
+	if err != nil {
+		return err
+	}
+	if len(content) > articleImportMaxFileSize {
+		return errors.New("文件过大")
+	}
+	params := vo.AdminArticleAddFileVo{AdminArticleAddMetaVo: vo.AdminArticleAddMetaVo{Author: author}}
+	markdown, deleted, err := applyFrontMatter(&params.AdminArticleAddMetaVo, string(content), file.Name)
+	if err != nil {
+		return err
+	}
+	input, err := article.createArticleFromFile(params, markdown, "")
+	if err != nil {
+		return err
+	}
+	item.Id, item.Title, item.Slug = input.Id.Hex(), input.Title, input.Slug
+	// 导出时在回收站中的文章导入之后同样放回回收站
+	if deleted {
+		if _, err := article.DeleteArticle(item.Id); err != nil {
+			return err
+		}
+		item.Trashed = true
+	}
+	return nil
+}
+
+// articleImportable 是否为需要导入的md文件，忽略目录、隐藏文件与macOS压缩时附带的元数据
+func articleImportable(file *zip.File) bool {
+	if file.FileInfo().IsDir() || strings.HasPrefix(file.Name, "__MACOSX/") {
+		return false
+	}
+	for _, part := range strings.Split(file.Name, "/") {
+		if strings.HasPrefix(part, ".") {
+			return false
+		}
+	}
+	ext := strings.ToLower(path.Ext(file.Name))
+	return ext == ".md" || ext == ".markdown"
+}
+
+// applyFrontMatter 解析front matter并补全未填写的元信息，返回去掉front matter之后的正文
+// 以及front matter是否标记了deleted；已填写的值优先；标题最终为空时使用文件名
+func applyFrontMatter(meta *vo.AdminArticleAddMetaVo, content string, fileName string) (string, bool, error) {
+	matter, markdown, err := utils.ParseFrontMatter(content)
+	if err != nil {
+		return "", false, err
+	}
+	if matter != nil {
+		if meta.Title == "" {
+			meta.Title = matter.Title
+		}
+		if meta.Author == "" {
+			meta.Author = matter.Author
+		}
+		if meta.Synopsis == "" {
+			meta.Synopsis = matter.Synopsis
+		}
+		if meta.PicUrl == "" {
+			meta.PicUrl = matter.PicUrl
+		}
+		if meta.Slug == "" {
+			meta.Slug = matter.Slug
+		}
+		if len(meta.Tags) == 0 {
+			meta.Tags = matter.Tags
+		}
+		if len(meta.Categories) == 0 {
+			meta.Categories = matter.Categories
+		}
+		meta.DraftFlag = meta.DraftFlag || matter.Draft
+		if meta.CreateTime.IsZero() {
+			meta.CreateTime = matter.Date
+		}
+		if meta.UpdateTime.IsZero() {
+			meta.UpdateTime = matter.Updated
+		}
+		if meta.PublishTime.IsZero() {
+			meta.PublishTime = matter.PublishDate
+		}
+	}
+	// Hugo的page bundle以目录名作为文章名
+	name := strings.TrimSuffix(path.Base(fileName), path.Ext(fileName))
+	if name == "index" || name == "_index" {
+		name = path.Base(path.Dir(fileName))
+	}
+	// Jekyll的文件名中带有日期
+	if match := jekyllPostName.FindStringSubmatch(name); match != nil {
+		name = match[2]
+		if date, err := time.ParseInLocation("2006-01-02", match[1], time.Local); err == nil && meta.CreateTime.IsZero() {
+			meta.CreateTime = date
+		}
+	}
+	if meta.Title == "" && name != "." && name != "/" {
+		meta.Title = name
+	}
+	return markdown, matter != nil && matter.Deleted, nil
+}
@@ -0,0 +1,97 @@
+package service
+
+import (
+	"r0Website-server/models/vo"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestApplyFrontMatter(t *testing.T) {
+	cases := []struct {
+		name     string
+		meta     vo.AdminArticleAddMetaVo
+		content  string
+		fileName string
+		want     vo.AdminArticleAddMetaVo
+		markdown string
+		deleted  bool
+	}{
+		{
+			name: "hexo",
+			content: "---\ntitle: Hello Hexo\ndate: 2020-01-02 10:00:00\nupdated: 2020-01-03 11:00:00\n" +
+				"tags:\n  - go\n  - web\ncategories:\n  - [Backend, Go]\ndescription: intro\n---\n\nbody\n",
+			fileName: "source/_posts/hello.md",
+			want: vo.AdminArticleAddMetaVo{
+				Title: "Hello Hexo", Synopsis: "intro",
+				Tags: []string{"go", "web"}, Categories: []string{"Backend", "Go"},
+				CreateTime: time.Date(2020, 1, 2, 10, 0, 0, 0, time.Local),
+				UpdateTime: time.Date(2020, 1, 3, 11, 0, 0, 0, time.Local),
+			},
+			markdown: "body\n",
+		},
+		{
+			name: "hugo toml in a page bundle",
+			content: "+++\ndraft = true\ndate = 2021-02-03T04:05:06+08:00\ntags = [\"go\"]\n" +
+				"categories = [\"notes\"]\ncover = \"/cover.png\"\n+++\nbody\n",
+			fileName: "content/posts/my-post/index.md",
+			want: vo.AdminArticleAddMetaVo{
+				Title: "my-post", PicUrl: "/cover.png", DraftFlag: true,
+				Tags: []string{"go"}, Categories: []string{"notes"},
+				CreateTime: time.Date(2021, 2, 3, 4, 5, 6, 0, time.FixedZone("", 8*3600)),
+			},
+			markdown: "body\n",
+		},
+		{
+			name:     "jekyll date from file name",
+			content:  "---\ntags: go web\ncategory: blog\npublished: false\ndeleted: true\n---\nbody\n",
+			fileName: "_posts/2019-05-06-hello-world.md",
+			want: vo.AdminArticleAddMetaVo{
+				Title: "hello-world", DraftFlag: true,
+				Tags: []string{"go", "web"}, Categories: []string{"blog"},
+				CreateTime: time.Date(2019, 5, 6, 0, 0, 0, 0, time.Local),
+			},
+			markdown: "body\n",
+			deleted:  true,
+		},
+		{
+			name:     "filled values win",
+			meta:     vo.AdminArticleAddMetaVo{Title: "Mine", Categories: []string{"kept"}},
+			content:  "---\ntitle: Theirs\ncategories: other\n---\nbody\n",
+			fileName: "post.md",
+			want:     vo.AdminArticleAddMetaVo{Title: "Mine", Categories: []string{"kept"}},
+			markdown: "body\n",
+		},
+		{
+			name:     "no front matter",
+			content:  "# title\n",
+			fileName: "plain.md",
+			want:     vo.AdminArticleAddMetaVo{Title: "plain"},
+			markdown: "# title\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			meta := c.meta
+			markdown, deleted, err := applyFrontMatter(&meta, c.content, c.fileName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if deleted != c.deleted {
+				t.Errorf("deleted = %v, want %v", deleted, c.deleted)
+			}
+			if markdown != c.markdown {
+				t.Errorf("markdown = %q, want %q", markdown, c.markdown)
+			}
+			// 时间单独比较，避免时区对象不同导致DeepEqual失败
+			if !meta.CreateTime.Equal(c.want.CreateTime) || !meta.UpdateTime.Equal(c.want.UpdateTime) {
+				t.Errorf("times = %v, %v, want %v, %v", meta.CreateTime, meta.UpdateTime, c.want.CreateTime, c.want.UpdateTime)
+			}
+			meta.CreateTime, meta.UpdateTime = time.Time{}, time.Time{}
+			c.want.CreateTime, c.want.UpdateTime = time.Time{}, time.Time{}
+			if !reflect.DeepEqual(meta, c.want) {
+				t.Errorf("meta = %+v, want %+v", meta, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,291 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 相关文章推荐，综合共同的标签、分类与分词的TF-IDF余弦相似度
+ * 	所有对外可见文章的向量在首次查询时构建并缓存，文章变动时失效
+ * @File:  article_related_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"math"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// relatedMaxSize 单次最多返回的相关文章数，缓存也按这个数量保存
+	relatedMaxSize = 20
+	// relatedCacheTTL 向量缓存的有效期，用于覆盖定时发布的文章到点后变为可见的情况
+	relatedCacheTTL = 10 * time.Minute
+	// relatedTitleWeight 标题中的词相对正文的权重
+	relatedTitleWeight = 3
+	// 各部分相似度的权重
+	relatedTextWeight     = 0.5
+	relatedTagWeight      = 0.35
+	relatedCategoryWeight = 0.15
+)
+
+// relatedDocument 一篇文章的摘要与归一化之后的TF-IDF向量
+type relatedDocument struct {
+	brief      vo.ArticleRelatedVo
+	tags       map[string]bool
+	categories map[string]bool
+	vector     map[string]float64
+}
+
+// relatedArticleCache 所有文章的向量与已经计算过的相关文章，文章详情与相关文章接口共用
+// generation在每次失效和重新构建时递增，用于丢弃基于旧向量计算出的结果
+var relatedArticleCache = struct {
+	sync.Mutex
+	documents  map[string]*relatedDocument
+	builtAt    time.Time
+	results    map[string][]vo.ArticleRelatedVo
+	generation uint64
+}{}
+
+// invalidateRelatedArticles 文章新增、修改、删除之后调用，下一次查询时重新构建
+func invalidateRelatedArticles() {
+	relatedArticleCache.Lock()
+	defer relatedArticleCache.Unlock()
+	relatedArticleCache.documents = nil
+	relatedArticleCache.results = nil
+	relatedArticleCache.generation++
+}
+
+// RelatedSize 文章详情中相关文章的数量
+func RelatedSize() int {
+	if global.Config != nil && global.Config.Article.RelatedSize > 0 {
+		return global.Config.Article.RelatedSize
+	}
+	return 5
+}
+
+// ArticleRelated 某一篇对外可见文章的相关文章
+func (article *ArticleService) ArticleRelated(
+	id string, params vo.BaseArticleRelatedVo,
+) (*vo.ArticleRelatedResultVo, error) {
+	size := params.Size
+	if size <= 0 {
+		size = RelatedSize()
+	}
+	if size > relatedMaxSize {
+		size = relatedMaxSize
+	}
+	related, ok, err := article.relatedArticles(id, size)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.New("ArticleRelated: 文章不存在")
+	}
+	return &vo.ArticleRelatedResultVo{Articles: related}, nil
+}
+
+// relatedArticles 返回前size篇相关文章，文章不在对外可见的文章中时ok为false
+func (article *ArticleService) relatedArticles(id string, size int) ([]vo.ArticleRelatedVo, bool, error) {
+	id = utils.String2HexString24(id)
+	documents, generation, err := article.relatedDocuments()
+	if err != nil {
+		return nil, false, err
+	}
+	current, ok := documents[id]
+	if !ok {
+		return nil, false, nil
+	}
+	relatedArticleCache.Lock()
+	related, ok := relatedArticleCache.results[id]
+	relatedArticleCache.Unlock()
+	if !ok {
+		related = rankRelatedDocuments(current, documents)
+		relatedArticleCache.Lock()
+		// 只在向量仍是当前缓存时保存结果
+		if relatedArticleCache.results != nil && relatedArticleCache.generation == generation {
+			relatedArticleCache.results[id] = related
+		}
+		relatedArticleCache.Unlock()
+	}
+	if len(related) > size {
+		related = related[:size]
+	}
+	// 缓存中的切片是共享的，返回副本
+	return append([]vo.ArticleRelatedVo{}, related...), true, nil
+}
+
+// relatedDocuments 返回缓存的文章向量，缓存失效时在锁外读取文章并构建，再在锁内替换
+func (article *ArticleService) relatedDocuments() (map[string]*relatedDocument, uint64, error) {
+	relatedArticleCache.Lock()
+	documents := relatedArticleCache.documents
+	generation := relatedArticleCache.generation
+	fresh := documents != nil && time.Since(relatedArticleCache.builtAt) <= relatedCacheTTL
+	relatedArticleCache.Unlock()
+	if fresh {
+		return documents, generation, nil
+	}
+	articles, err := article.ArticleDao.ArticlesForRelated()
+	if err != nil {
+		return nil, 0, err
+	}
+	documents = buildRelatedDocuments(articles)
+	relatedArticleCache.Lock()
+	defer relatedArticleCache.Unlock()
+	// 构建期间缓存被失效时，本次结果可能已经过时，只用于当前查询
+	if relatedArticleCache.generation != generation {
+		return documents, generation, nil
+	}
+	relatedArticleCache.generation++
+	relatedArticleCache.documents = documents
+	relatedArticleCache.builtAt = time.Now()
+	relatedArticleCache.results = map[string][]vo.ArticleRelatedVo{}
+	return documents, relatedArticleCache.generation, nil
+}
+
+// buildRelatedDocuments 为所有文章计算归一化的TF-IDF向量
+func buildRelatedDocuments(articles []po.Article) map[string]*relatedDocument {
+	documents := make(map[string]*relatedDocument, len(articles))
+	frequencies := make(map[string]map[string]float64, len(articles))
+	documentFrequency := map[string]int{}
+	for _, val := range articles {
+		id := val.Id.Hex()
+		frequency := map[string]float64{}
+		for _, term := range relatedTerms(val.MdWords) {
+			frequency[term]++
+		}
+		for _, term := range relatedTerms(val.TitleWords) {
+			frequency[term] += relatedTitleWeight
+		}
+		for term := range frequency {
+			documentFrequency[term]++
+		}
+		frequencies[id] = frequency
+		documents[id] = &relatedDocument{
+			brief: vo.ArticleRelatedVo{
+				Id:         id,
+				Title:      val.Title,
+				Slug:       val.Slug,
+				Synopsis:   val.Synopsis,
+				PicUrl:     val.PicUrl,
+				Tags:       val.Tags,
+				CreateTime: val.CreateTime.Local(),
+			},
+			tags:       stringSet(val.Tags),
+			categories: stringSet(val.Categories),
+		}
+	}
+	total := float64(len(articles))
+	for id, frequency := range frequencies {
+		vector := make(map[string]float64, len(frequency))
+		var norm float64
+		for term, count := range frequency {
+			weight := (1 + math.Log(count)) * math.Log(1+total/float64(documentFrequency[term]))
+			vector[term] = weight
+			norm += weight * weight
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for term := range vector {
+				vector[term] /= norm
+			}
+		}
+		documents[id].vector = vector
+	}
+	return documents
+}
+
+// rankRelatedDocuments 按相关度倒序排列除自身以外的文章，相关度相同时较新的文章靠前
+func rankRelatedDocuments(current *relatedDocument, documents map[string]*relatedDocument) []vo.ArticleRelatedVo {
+	related := make([]vo.ArticleRelatedVo, 0)
+	for id, other := range documents {
+		if id == current.brief.Id {
+			continue
+		}
+		score := relatedTextWeight*cosineSimilarity(current.vector, other.vector) +
+			relatedTagWeight*jaccardSimilarity(current.tags, other.tags) +
+			relatedCategoryWeight*jaccardSimilarity(current.categories, other.categories)
+		if score <= 0 {
+			continue
+		}
+		brief := other.brief
+		brief.Score = math.Round(score*10000) / 10000
+		related = append(related, brief)
+	}
+	sort.Slice(related, func(i, j int) bool {
+		if related[i].Score != related[j].Score {
+			return related[i].Score > related[j].Score
+		}
+		return related[i].CreateTime.After(related[j].CreateTime)
+	})
+	if len(related) > relatedMaxSize {
+		related = related[:relatedMaxSize]
+	}
+	return related
+}
+
+// relatedTerms 从空格分隔的分词结果中取出有意义的词，忽略单字与标点
+func relatedTerms(words string) []string {
+	terms := make([]string, 0)
+	for _, word := range strings.Fields(strings.ToLower(words)) {
+		if utf8.RuneCountInString(word) < 2 {
+			continue
+		}
+		meaningful := false
+		for _, r := range word {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				meaningful = true
+				break
+			}
+		}
+		if meaningful {
+			terms = append(terms, word)
+		}
+	}
+	return terms
+}
+
+// cosineSimilarity 两个已归一化向量的余弦相似度
+func cosineSimilarity(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot float64
+	for term, weight := range a {
+		dot += weight * b[term]
+	}
+	return dot
+}
+
+// jaccardSimilarity 两个集合的交集与并集之比
+func jaccardSimilarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	intersection := 0
+	for item := range a {
+		if b[item] {
+			intersection++
+		}
+	}
+	return float64(intersection) / float64(len(a)+len(b)-intersection)
+}
+
+func stringSet(items []string) map[string]bool {
+	set := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item != "" {
+			set[item] = true
+		}
+	}
+	return set
+}
@@ -0,0 +1,74 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章的服务端渲染，按文章的更新时间缓存渲染结果
+ * @File:  article_render_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"strconv"
+	"sync"
+)
+
+// ArticleRenderHtml 返回服务端渲染的html与目录
+const ArticleRenderHtml = "html"
+
+// articleRenderCacheSize 渲染缓存的最大文章数，超过之后淘汰最早放入的
+const articleRenderCacheSize = 256
+
+type renderedArticle struct {
+	html string
+	toc  []utils.TocNode
+}
+
+// articleRenderCache 渲染结果的缓存，key为文章id与更新时间，文章更新之后旧的缓存自然失效
+// 未注册到容器的组件在每个注入点各有一个实例，需要共享的状态因此放在包级别，其他服务的缓存同理
+var articleRenderCache = struct {
+	sync.Mutex
+	items map[string]*renderedArticle
+	order []string
+}{items: map[string]*renderedArticle{}}
+
+// HighlightCSS 代码高亮的css，style为空时使用默认配色
+func (article *ArticleService) HighlightCSS(style string) (string, error) {
+	return utils.HighlightCSS(style)
+}
+
+// renderArticles 为文章填充html与目录
+func renderArticles(articles []vo.SingleBaseArticleSearchResultVo) {
+	for index := range articles {
+		rendered := renderArticle(&articles[index])
+		articles[index].Html = rendered.html
+		articles[index].Toc = rendered.toc
+	}
+}
+
+// renderArticle 渲染一篇文章，命中缓存时直接返回
+func renderArticle(article *vo.SingleBaseArticleSearchResultVo) *renderedArticle {
+	key := article.Id.Hex() + ":" + strconv.FormatInt(article.UpdateTime.UnixNano(), 10)
+	articleRenderCache.Lock()
+	rendered, ok := articleRenderCache.items[key]
+	articleRenderCache.Unlock()
+	if ok {
+		return rendered
+	}
+	html, toc := utils.Markdown2HtmlWithToc(article.Markdown)
+	rendered = &renderedArticle{html: html, toc: toc}
+	articleRenderCache.Lock()
+	defer articleRenderCache.Unlock()
+	if _, ok := articleRenderCache.items[key]; !ok {
+		articleRenderCache.items[key] = rendered
+		articleRenderCache.order = append(articleRenderCache.order, key)
+		for len(articleRenderCache.order) > articleRenderCacheSize {
+			delete(articleRenderCache.items, articleRenderCache.order[0])
+			articleRenderCache.order = articleRenderCache.order[1:]
+		}
+	}
+	return rendered
+}
@@ -0,0 +1,211 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章的历史版本：快照、比较与恢复
+ * @File:  article_revision_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"fmt"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"strings"
+	"time"
+)
+
+// ArticleBackup 手动为文章当前的内容做一次快照
+func (article *ArticleService) ArticleBackup(id string) (*vo.ArticleRevisionVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	revision := newArticleRevision(origin, "backup")
+	if err := article.ArticleRevisionDao.CreateRevision(revision); err != nil {
+		return nil, err
+	}
+	ans := articleRevisionToVo(revision)
+	ans.Markdown = ""
+	return ans, nil
+}
+
+// ArticleRevisions 文章的历史版本列表
+func (article *ArticleService) ArticleRevisions(id string) (*vo.ArticleRevisionListVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	revisions, err := article.ArticleRevisionDao.ListRevisions(origin.Id)
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.ArticleRevisionListVo{
+		ArticleId: origin.Id,
+		Revisions: make([]vo.ArticleRevisionVo, 0, len(revisions)),
+	}
+	for index := range revisions {
+		result.Revisions = append(result.Revisions, *articleRevisionToVo(&revisions[index]))
+	}
+	result.TotalCount = int64(len(result.Revisions))
+	return result, nil
+}
+
+// ArticleRevision 文章的某一个历史版本
+func (article *ArticleService) ArticleRevision(id string, version int64) (*vo.ArticleRevisionVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := article.ArticleRevisionDao.FindRevision(origin.Id, version)
+	if err != nil {
+		return nil, err
+	}
+	return articleRevisionToVo(revision), nil
+}
+
+// ArticleRevisionDiff 比较文章的两个版本，版本号为0时表示文章当前的内容
+func (article *ArticleService) ArticleRevisionDiff(
+	id string, params vo.ArticleRevisionDiffParamsVo,
+) (*vo.ArticleRevisionDiffVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	from, err := article.revisionOrCurrent(origin, params.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := article.revisionOrCurrent(origin, params.To)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.ArticleRevisionDiffVo{
+		ArticleId: origin.Id,
+		From:      params.From,
+		To:        params.To,
+		Diff: utils.UnifiedDiff(
+			revisionText(from), revisionText(to), revisionName(params.From), revisionName(params.To), 3,
+		),
+	}, nil
+}
+
+// ArticleRevisionRestore 将文章恢复到某一个历史版本，恢复前的内容同样会留下快照
+func (article *ArticleService) ArticleRevisionRestore(
+	id string, version int64,
+) (*vo.ArticleRevisionRestoreResultVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := article.ArticleRevisionDao.FindRevision(origin.Id, version)
+	if err != nil {
+		return nil, err
+	}
+	updateResult, err := article.updateArticle(origin, vo.AdminArticleUpdateVo{
+		Title:      &revision.Title,
+		Author:     &revision.Author,
+		Synopsis:   &revision.Synopsis,
+		Markdown:   &revision.Markdown,
+		Tags:       &revision.Tags,
+		Categories: &revision.Categories,
+		DraftFlag:  &revision.DraftFlag,
+		Overhead:   &revision.Overhead,
+		PicUrl:     &revision.PicUrl,
+		// 旧的快照没有发布时间，此时保留文章当前的发布时间
+		PublishTime: optionalTime(revision.PublishTime),
+	}, "restore")
+	if err != nil {
+		return nil, err
+	}
+	return &vo.ArticleRevisionRestoreResultVo{
+		AdminArticleUpdateResultVo: *updateResult,
+		RestoredVersion:            version,
+	}, nil
+}
+
+// revisionOrCurrent 版本号为0时用文章当前的内容构造一个临时版本
+func (article *ArticleService) revisionOrCurrent(origin *po.Article, version int64) (*po.ArticleRevision, error) {
+	if version == 0 {
+		return newArticleRevision(origin, "current"), nil
+	}
+	return article.ArticleRevisionDao.FindRevision(origin.Id, version)
+}
+
+// newArticleRevision 用文章当前的内容构造快照，版本号由DAO在保存时分配
+func newArticleRevision(origin *po.Article, reason string) *po.ArticleRevision {
+	return &po.ArticleRevision{
+		ArticleId:         origin.Id,
+		Reason:            reason,
+		Title:             origin.Title,
+		Author:            origin.Author,
+		Synopsis:          origin.Synopsis,
+		PicUrl:            origin.PicUrl,
+		Markdown:          origin.Markdown,
+		DraftFlag:         origin.DraftFlag,
+		Overhead:          origin.Overhead,
+		PublishTime:       origin.PublishTime,
+		ArtLength:         origin.ArtLength,
+		Tags:              origin.Tags,
+		Categories:        origin.Categories,
+		ArticleUpdateTime: origin.UpdateTime,
+	}
+}
+
+// revisionText 将版本渲染为便于逐行比较的文本，元信息在前，md内容在后
+func revisionText(revision *po.ArticleRevision) string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("title: %s\n", revision.Title))
+	builder.WriteString(fmt.Sprintf("author: %s\n", revision.Author))
+	builder.WriteString(fmt.Sprintf("synopsis: %s\n", revision.Synopsis))
+	builder.WriteString(fmt.Sprintf("pic_url: %s\n", revision.PicUrl))
+	builder.WriteString(fmt.Sprintf("tags: %s\n", strings.Join(revision.Tags, ", ")))
+	builder.WriteString(fmt.Sprintf("categories: %s\n", strings.Join(revision.Categories, ", ")))
+	builder.WriteString(fmt.Sprintf("draft_flag: %t\n", revision.DraftFlag))
+	builder.WriteString(fmt.Sprintf("overhead: %t\n", revision.Overhead))
+	builder.WriteString(fmt.Sprintf("publish_time: %s\n", revision.PublishTime.Local().Format(time.RFC3339)))
+	builder.WriteString("---\n")
+	builder.WriteString(revision.Markdown)
+	return builder.String()
+}
+
+// revisionName diff头部中版本的名字
+func revisionName(version int64) string {
+	if version == 0 {
+		return "current"
+	}
+	return fmt.Sprintf("revision-%d", version)
+}
+
+// optionalTime 零值时间转换为nil
+func optionalTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func articleRevisionToVo(revision *po.ArticleRevision) *vo.ArticleRevisionVo {
+	return &vo.ArticleRevisionVo{
+		Id:                revision.Id,
+		ArticleId:         revision.ArticleId,
+		Version:           revision.Version,
+		Reason:            revision.Reason,
+		Title:             revision.Title,
+		Author:            revision.Author,
+		Synopsis:          revision.Synopsis,
+		PicUrl:            revision.PicUrl,
+		Markdown:          revision.Markdown,
+		DraftFlag:         revision.DraftFlag,
+		Overhead:          revision.Overhead,
+		PublishTime:       revision.PublishTime.Local(),
+		ArtLength:         revision.ArtLength,
+		Tags:              revision.Tags,
+		Categories:        revision.Categories,
+		ArticleUpdateTime: revision.ArticleUpdateTime.Local(),
+		CreateTime:        revision.CreateTime.Local(),
+	}
+}
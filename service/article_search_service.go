@@ -0,0 +1,326 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 进程内全文索引的维护与检索，配置search.backend为index时代替Mongo的$text检索
+ * 	索引以文章的更新时间作为版本，启动时只重新索引变动过的文章
+ * @File:  article_search_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"os"
+	"r0Website-server/global"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"r0Website-server/utils/fulltext"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SearchBackendMongo 使用Mongo的$text检索
+	SearchBackendMongo = "mongo"
+	// SearchBackendIndex 使用进程内的倒排索引
+	SearchBackendIndex = "index"
+	// searchIndexSaveInterval 索引有变动时保存到磁盘的间隔
+	searchIndexSaveInterval = 30 * time.Second
+	// searchIndexBatchSize 重新索引时每次从Mongo读取的文章数
+	searchIndexBatchSize = 100
+)
+
+// articleSearchIndex 进程内唯一的文章全文索引，搜索、文章变动与重建共用
+// 初始化与重建由building串行执行，初始化失败时不记录状态，下一次调用重新尝试
+var articleSearchIndex = struct {
+	sync.RWMutex
+	building sync.Mutex
+	index    *fulltext.Index
+	saving   bool
+}{}
+
+// currentSearchIndex 当前使用的索引，尚未初始化时为nil
+func currentSearchIndex() *fulltext.Index {
+	articleSearchIndex.RLock()
+	defer articleSearchIndex.RUnlock()
+	return articleSearchIndex.index
+}
+
+// newArticleSearchIndex 标题的权重高于标签，标签高于正文
+func newArticleSearchIndex() *fulltext.Index {
+	return fulltext.NewIndex(utils.SearchTokens,
+		fulltext.Field{Name: "title", Boost: 3},
+		fulltext.Field{Name: "tags", Boost: 2},
+		fulltext.Field{Name: "body", Boost: 1},
+	)
+}
+
+// SearchBackend 配置的搜索后端
+func SearchBackend() string {
+	if global.Config != nil && global.Config.Search.Backend == SearchBackendIndex {
+		return SearchBackendIndex
+	}
+	return SearchBackendMongo
+}
+
+// SearchIndexPath 倒排索引的持久化路径
+func SearchIndexPath() string {
+	if global.Config != nil && global.Config.Search.IndexPath != "" {
+		return global.Config.Search.IndexPath
+	}
+	return "./data/article_search.index"
+}
+
+// InitSearchIndex 加载磁盘上的索引并与数据库同步，之后定期保存，成功之后不再重复执行
+func (article *ArticleService) InitSearchIndex() error {
+	if currentSearchIndex() != nil {
+		return nil
+	}
+	articleSearchIndex.building.Lock()
+	defer articleSearchIndex.building.Unlock()
+	if currentSearchIndex() != nil {
+		return nil
+	}
+	index := newArticleSearchIndex()
+	if err := index.Load(SearchIndexPath()); err != nil && !os.IsNotExist(err) {
+		global.Logger.Warnf("加载文章索引失败，将重新建立: %v", err)
+	}
+	count, err := article.syncSearchIndex(index)
+	if err != nil {
+		return err
+	}
+	global.Logger.Infof("文章索引就绪: %d篇文章，本次重新索引%d篇", index.Len(), count)
+	if err := index.Save(SearchIndexPath()); err != nil {
+		global.Logger.Errorf("保存文章索引失败: %v", err)
+	}
+	useSearchIndex(index)
+	return nil
+}
+
+// useSearchIndex 替换当前使用的索引，第一次替换时开始定期保存，调用方需持有building
+func useSearchIndex(index *fulltext.Index) {
+	articleSearchIndex.Lock()
+	defer articleSearchIndex.Unlock()
+	articleSearchIndex.index = index
+	if !articleSearchIndex.saving {
+		articleSearchIndex.saving = true
+		go saveSearchIndexPeriodically()
+	}
+}
+
+// RebuildSearchIndex 从数据库重新建立索引，建立完成之前继续使用旧的索引
+// 不依赖之前的初始化结果，初始化失败之后也可以通过重建恢复
+func (article *ArticleService) RebuildSearchIndex() (*vo.AdminSearchIndexVo, error) {
+	if SearchBackend() != SearchBackendIndex {
+		return nil, errors.New("RebuildSearchIndex: 当前的搜索后端不是" + SearchBackendIndex)
+	}
+	articleSearchIndex.building.Lock()
+	defer articleSearchIndex.building.Unlock()
+	index := newArticleSearchIndex()
+	if _, err := article.syncSearchIndex(index); err != nil {
+		return nil, err
+	}
+	if err := index.Save(SearchIndexPath()); err != nil {
+		global.Logger.Error(err)
+		return nil, err
+	}
+	useSearchIndex(index)
+	// 重建期间的变动只更新到了旧的索引，替换之后按版本再补一次
+	if _, err := article.syncSearchIndex(index); err != nil {
+		return nil, err
+	}
+	return article.SearchIndexStatus()
+}
+
+// SearchIndexStatus 索引的状态，索引尚未初始化时只返回配置
+func (article *ArticleService) SearchIndexStatus() (*vo.AdminSearchIndexVo, error) {
+	result := &vo.AdminSearchIndexVo{Backend: SearchBackend(), Path: SearchIndexPath()}
+	if index := currentSearchIndex(); index != nil {
+		result.Ready = true
+		result.Documents = int64(index.Len())
+		result.Terms = int64(index.Terms())
+		result.Dirty = index.Dirty()
+	}
+	return result, nil
+}
+
+// syncSearchIndex 将索引与数据库同步：重新索引新增与更新过的文章，移除已经不存在或在回收站中的文章
+func (article *ArticleService) syncSearchIndex(index *fulltext.Index) (int, error) {
+	current, err := article.ArticleDao.IndexableArticleVersions()
+	if err != nil {
+		return 0, err
+	}
+	versions := index.Versions()
+	stale := make([]primitive.ObjectID, 0)
+	for _, val := range current {
+		id := val.Id.Hex()
+		if version, ok := versions[id]; !ok || version != val.UpdateTime.UnixNano() {
+			stale = append(stale, val.Id)
+		}
+		delete(versions, id)
+	}
+	for id := range versions {
+		index.Remove(id)
+	}
+	for start := 0; start < len(stale); start += searchIndexBatchSize {
+		end := start + searchIndexBatchSize
+		if end > len(stale) {
+			end = len(stale)
+		}
+		articles, err := article.ArticleDao.IndexableArticles(stale[start:end])
+		if err != nil {
+			return 0, err
+		}
+		for i := range articles {
+			indexArticle(index, &articles[i])
+		}
+	}
+	return len(stale), nil
+}
+
+// reindexArticles 文章变动之后更新索引，索引尚未初始化时跳过，初始化时会按版本补上
+func (article *ArticleService) reindexArticles(ids ...primitive.ObjectID) {
+	index := currentSearchIndex()
+	if index == nil || len(ids) == 0 {
+		return
+	}
+	articles, err := article.ArticleDao.IndexableArticles(ids)
+	if err != nil {
+		global.Logger.Errorf("更新文章索引失败: %v", err)
+		return
+	}
+	found := make(map[string]bool, len(articles))
+	for i := range articles {
+		indexArticle(index, &articles[i])
+		found[articles[i].Id.Hex()] = true
+	}
+	for _, id := range ids {
+		if !found[id.Hex()] {
+			index.Remove(id.Hex())
+		}
+	}
+}
+
+// indexArticle 索引一篇文章的标题、标签与正文
+func indexArticle(index *fulltext.Index, val *po.Article) {
+	index.Add(val.Id.Hex(), val.UpdateTime.UnixNano(),
+		val.Title, strings.Join(val.Tags, " "), utils.MarkdownPlainText(val.Markdown),
+	)
+}
+
+// saveSearchIndexPeriodically 定期将有变动的索引保存到磁盘，阻塞运行
+func saveSearchIndexPeriodically() {
+	ticker := time.NewTicker(searchIndexSaveInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		index := currentSearchIndex()
+		if !index.Dirty() {
+			continue
+		}
+		if err := index.Save(SearchIndexPath()); err != nil {
+			global.Logger.Errorf("保存文章索引失败: %v", err)
+		}
+	}
+}
+
+// searchArticles 按配置的搜索后端检索对外可见的文章
+func (article *ArticleService) searchArticles(
+	params vo.BaseArticleSearchVo,
+) (*vo.BaseArticleSearchResultVo, error) {
+	if SearchBackend() == SearchBackendIndex {
+		return article.indexArticleSearch(params)
+	}
+	return article.ArticleDao.ArticleBaseSearch(params, "")
+}
+
+// indexArticleSearch 用倒排索引检索对外可见的文章
+// 未指定时间排序时按相关度排序，分页规则与Mongo检索一致
+func (article *ArticleService) indexArticleSearch(
+	params vo.BaseArticleSearchVo,
+) (*vo.BaseArticleSearchResultVo, error) {
+	if params.UpdateTimeSort.SortFlag && params.CreateTimeSort.SortFlag {
+		return nil, errors.New("ArticleBaseSearch: " + "不能同时指定UpdateTime和CreateTime的排序")
+	}
+	if err := article.InitSearchIndex(); err != nil {
+		return nil, err
+	}
+	pageNumber, pageSize := params.PageNumber, params.PageSize
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize >= 200 {
+		pageSize = 200
+	}
+	result := &vo.BaseArticleSearchResultVo{
+		Articles: []vo.SingleBaseArticleSearchResultVo{}, PageNumber: pageNumber, PageSize: pageSize,
+	}
+	hits := currentSearchIndex().Search(params.SearchText)
+	if len(hits) == 0 {
+		return result, nil
+	}
+	scores := make(map[string]float64, len(hits))
+	ids := make([]primitive.ObjectID, 0, len(hits))
+	for _, hit := range hits {
+		if id, err := primitive.ObjectIDFromHex(hit.Id); err == nil {
+			scores[hit.Id] = hit.Score
+			ids = append(ids, id)
+		}
+	}
+	// 草稿、定时发布等对外不可见的文章同样在索引中，需要按数据库当前的状态过滤
+	visible, err := article.ArticleDao.VisibleArticlesAmong(ids, params.Author)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(visible, func(i, j int) bool {
+		switch {
+		case params.UpdateTimeSort.SortFlag && !visible[i].UpdateTime.Equal(visible[j].UpdateTime):
+			return visible[i].UpdateTime.Before(visible[j].UpdateTime) == (params.UpdateTimeSort.SortDirection > 0)
+		case params.CreateTimeSort.SortFlag && !visible[i].CreateTime.Equal(visible[j].CreateTime):
+			return visible[i].CreateTime.Before(visible[j].CreateTime) == (params.CreateTimeSort.SortDirection > 0)
+		}
+		left, right := scores[visible[i].Id.Hex()], scores[visible[j].Id.Hex()]
+		if left != right {
+			return left > right
+		}
+		return visible[i].Id.Hex() < visible[j].Id.Hex()
+	})
+	result.TotalCount = int64(len(visible))
+	start := (pageNumber - 1) * pageSize
+	if start >= result.TotalCount {
+		return result, nil
+	}
+	end := start + pageSize
+	if end > result.TotalCount {
+		end = result.TotalCount
+	}
+	pageIds := make([]primitive.ObjectID, 0, end-start)
+	for _, val := range visible[start:end] {
+		pageIds = append(pageIds, val.Id)
+	}
+	articles, err := article.ArticleDao.ArticlesByIds(pageIds, params.Lazy)
+	if err != nil {
+		return nil, err
+	}
+	position := make(map[string]int, len(pageIds))
+	for index, id := range pageIds {
+		position[id.Hex()] = index
+	}
+	sort.Slice(articles, func(i, j int) bool {
+		return position[articles[i].Id.Hex()] < position[articles[j].Id.Hex()]
+	})
+	for index := range articles {
+		articles[index].Score = scores[articles[index].Id.Hex()]
+	}
+	result.Articles = articles
+	result.AnsCount = int64(len(articles))
+	return result, nil
+}
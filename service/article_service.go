@@ -11,7 +11,6 @@ package service
 
 import (
 	"errors"
-	"fmt"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"io/ioutil"
 	"r0Website-server/dao"
@@ -24,64 +23,123 @@ import (
 )
 
 type ArticleService struct {
-	ArticleDao  *dao.ArticleDao  `R0Ioc:"true"`
-	CategoryDao *dao.CategoryDao `R0Ioc:"true"`
+	ArticleDao         *dao.ArticleDao         `R0Ioc:"true"`
+	CategoryDao        *dao.CategoryDao        `R0Ioc:"true"`
+	ArticleRevisionDao *dao.ArticleRevisionDao `R0Ioc:"true"`
+	CommentDao         *dao.CommentDao         `R0Ioc:"true"`
+	SeriesDao          *dao.SeriesDao          `R0Ioc:"true"`
+	ImageDao           *dao.ImageDao           `R0Ioc:"true"`
+	ArticlePraiseDao   *dao.ArticlePraiseDao   `R0Ioc:"true"`
+	ArticleStatDao     *dao.ArticleStatDao     `R0Ioc:"true"`
+	// 计数器需要是单例，由容器注册
+	ArticleCounterService *ArticleCounterService `R0Ioc:"true"`
 }
 
-// AddPraise 增加一次点赞
-func (article *ArticleService) AddPraise(id string) (*vo.BaseArticleSetPVResultVo, error) {
-	if res, err := article.ArticleDao.AddPraise(id); err != nil {
+// AddPraise 增加一次点赞，每位访客对一篇文章只计一次
+func (article *ArticleService) AddPraise(id string, visit bo.ArticleVisit) (*vo.BaseArticleSetPVResultVo, error) {
+	bsonId, err := article.ArticleDao.VisibleArticleId(id)
+	if err != nil {
 		return nil, err
-	} else {
-		return &vo.BaseArticleSetPVResultVo{
-			MatchedCount:  res.MatchedCount,
-			ModifiedCount: res.ModifiedCount,
-			UpsertedCount: res.UpsertedCount,
-			Id:            id,
-		}, nil
 	}
+	counted, err := article.ArticleCounterService.Praise(bsonId, visitorFingerprint(visit), visit.Ip)
+	if err != nil {
+		return nil, err
+	}
+	return counterResult(id, counted), nil
 }
 
-// AddPV 增加一次PV
-func (article *ArticleService) AddPV(id string) (*vo.BaseArticleSetPVResultVo, error) {
-	if res, err := article.ArticleDao.AddPV(id); err != nil {
+// RemovePraise 取消点赞
+func (article *ArticleService) RemovePraise(id string, visit bo.ArticleVisit) (*vo.BaseArticleSetPVResultVo, error) {
+	bsonId, err := article.ArticleDao.VisibleArticleId(id)
+	if err != nil {
 		return nil, err
-	} else {
-		return &vo.BaseArticleSetPVResultVo{
-			MatchedCount:  res.MatchedCount,
-			ModifiedCount: res.ModifiedCount,
-			UpsertedCount: res.UpsertedCount,
-			Id:            id,
-		}, nil
 	}
+	counted, err := article.ArticleCounterService.Unpraise(bsonId, visitorFingerprint(visit))
+	if err != nil {
+		return nil, err
+	}
+	return counterResult(id, counted), nil
+}
+
+// AddPV 增加一次PV，同一访客在去重时长内重复阅读只计一次，计入的阅读同时进入每日统计
+func (article *ArticleService) AddPV(id string, visit bo.ArticleVisit) (*vo.BaseArticleSetPVResultVo, error) {
+	bsonId, slug, err := article.ArticleDao.VisibleArticleSlug(id)
+	if err != nil {
+		return nil, err
+	}
+	counted := article.ArticleCounterService.View(bsonId, slug, visitorFingerprint(visit), visit)
+	return counterResult(id, counted), nil
+}
+
+// counterResult 计数之后的返回模型，计数器的增量是异步写入的，因此按是否计数填写
+func counterResult(id string, counted bool) *vo.BaseArticleSetPVResultVo {
+	result := &vo.BaseArticleSetPVResultVo{MatchedCount: 1, Id: id, Counted: counted}
+	if counted {
+		result.ModifiedCount = 1
+	}
+	return result
 }
 
 // ArticleADDFile 通过上传文件增加文章
+// 文件开头有front matter时用它补全表单中未填写的元信息，front matter本身不会保存到正文中
 func (article *ArticleService) ArticleADDFile(
 	params vo.AdminArticleAddFileVo, id string,
 ) (ans *vo.AdminArticleAddFileResultVo, err error) {
-	var result vo.AdminArticleAddFileResultVo
-	var input po.Article
+	if params.File == nil {
+		return nil, errors.New("ArticleADDFile: 缺少上传的文件")
+	}
 	// 获取文章内容
 	open, err := params.File.Open()
 	if err != nil {
 		global.Logger.Error(err)
 		return nil, err
 	}
+	defer open.Close()
 	content, err := ioutil.ReadAll(open)
 	if err != nil {
 		global.Logger.Error(err)
 		return nil, errors.New("ArticleADDFile: 获取文件内容失败")
 	}
-	input.Markdown = string(content)
+	markdown, _, err := applyFrontMatter(&params.AdminArticleAddMetaVo, string(content), params.File.Filename)
+	if err != nil {
+		return nil, err
+	}
+	// 已存在的文章直接覆盖，覆盖前会留下快照
+	if origin := article.existingArticle(id); origin != nil {
+		updateResult, err := article.updateArticle(
+			origin, articleMetaToUpdateParams(params.AdminArticleAddMetaVo, markdown), "overwrite",
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &vo.AdminArticleAddFileResultVo{Title: updateResult.Title, Id: updateResult.Id}, nil
+	}
+	input, err := article.createArticleFromFile(params, markdown, id)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.AdminArticleAddFileResultVo{Title: input.Title, Id: input.Id}, nil
+}
+
+// createArticleFromFile 用上传文件的元信息与md内容创建新文章
+func (article *ArticleService) createArticleFromFile(
+	params vo.AdminArticleAddFileVo, markdown string, id string,
+) (*po.Article, error) {
+	var input po.Article
+	var err error
+	input.Markdown = markdown
 	updateArticleMetaByParams(&input, params, id)
+	if input.Slug, err = article.newArticleSlug(&input, params.Slug); err != nil {
+		return nil, err
+	}
 	insertResult, err := article.ArticleDao.CreateArticle(&input)
 	if err != nil {
 		global.Logger.Error(err)
-	} else {
-		result = vo.AdminArticleAddFileResultVo{Title: input.Title, Id: insertResult.InsertedID.(primitive.ObjectID)}
+		return nil, err
 	}
-	return &result, err
+	input.Id = insertResult.InsertedID.(primitive.ObjectID)
+	article.articlesChanged(input.Id)
+	return &input, nil
 }
 
 // ArticleADDForm 通过提交表单增加文章
@@ -91,17 +149,96 @@ func (article *ArticleService) ArticleADDForm(
 	var result vo.AdminArticleAddFormResultVo
 	var input po.Article
 	input.Markdown = params.Markdown
-
+	// 已存在的文章直接覆盖，覆盖前会留下快照
+	if origin := article.existingArticle(id); origin != nil {
+		updateResult, err := article.updateArticle(
+			origin, articleMetaToUpdateParams(params.AdminArticleAddMetaVo, params.Markdown), "overwrite",
+		)
+		if err != nil {
+			return nil, err
+		}
+		return &vo.AdminArticleAddFormResultVo{Title: updateResult.Title, Id: updateResult.Id}, nil
+	}
 	updateArticleMetaByParams(&input, params, id)
+	if input.Slug, err = article.newArticleSlug(&input, params.Slug); err != nil {
+		return nil, err
+	}
 	insertResult, err := article.ArticleDao.CreateArticle(&input)
 	if err != nil {
 		global.Logger.Error(err)
 	} else {
 		result = vo.AdminArticleAddFormResultVo{Title: input.Title, Id: insertResult.InsertedID.(primitive.ObjectID)}
+		article.articlesChanged(result.Id)
 	}
 	return &result, err
 }
 
+// ArticleUpdate 局部更新文章
+// 保留阅读数、点赞数、评论数与创建时间，仅对实际变动的分类维护倒排
+func (article *ArticleService) ArticleUpdate(
+	params vo.AdminArticleUpdateVo, id string,
+) (*vo.AdminArticleUpdateResultVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	return article.updateArticle(origin, params, "update")
+}
+
+// updateArticle 用参数修补文章，保存之前对旧内容做快照，保存失败时删除快照
+func (article *ArticleService) updateArticle(
+	origin *po.Article, params vo.AdminArticleUpdateVo, reason string,
+) (*vo.AdminArticleUpdateResultVo, error) {
+	input := *origin
+	patchArticleByParams(&input, params)
+	if err := article.assignArticleSlug(&input, origin, params.Slug); err != nil {
+		return nil, err
+	}
+	revision := newArticleRevision(origin, reason)
+	if err := article.ArticleRevisionDao.CreateRevision(revision); err != nil {
+		return nil, err
+	}
+	updateResult, err := article.ArticleDao.UpdateArticle(&input)
+	if err != nil {
+		if deleteErr := article.ArticleRevisionDao.DeleteRevision(revision.Id); deleteErr != nil {
+			global.Logger.Errorf("删除未生效的文章快照失败: %v", deleteErr)
+		}
+		return nil, err
+	}
+	// 与ArticleOverhead一样同时维护置顶的顺序与到期时间，重新置顶已经到期的文章时清除到期时间
+	if overheadChanged(origin, params.Overhead) {
+		overheadResult, err := article.ArticleDao.SetOverhead(input.Id, input.Overhead, origin.OverheadOrder, nil)
+		if err != nil {
+			return nil, err
+		}
+		if updateResult.ModifiedCount == 0 {
+			updateResult.ModifiedCount = overheadResult.ModifiedCount
+		}
+	}
+	article.articlesChanged(input.Id)
+	result := &vo.AdminArticleUpdateResultVo{
+		Title:              input.Title,
+		Id:                 input.Id,
+		Slug:               input.Slug,
+		ModifiedCount:      updateResult.ModifiedCount,
+		ArchivedCategories: diffStringSlice(input.Categories, origin.Categories),
+		RemovedCategories:  diffStringSlice(origin.Categories, input.Categories),
+	}
+	// 只维护变动的分类，未变动的分类不会被重复计数
+	for _, category := range result.ArchivedCategories {
+		if _, err := article.CategoryDao.ArchiveArticle(input.Id.Hex(), category); err != nil {
+			global.Logger.Error(err)
+			return nil, err
+		}
+	}
+	if len(result.RemovedCategories) > 0 {
+		if _, err := article.CategoryDao.RemoveArticle(result.RemovedCategories, input.Id.Hex()); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
 // ArticleBaseSearch 基础权限的文章搜索功能
 // 可选作者，可选模糊内容，可选两种时间排序，可选id
 // **如果使用id检索，其他检索全部失效**
@@ -109,7 +246,53 @@ func (article *ArticleService) ArticleADDForm(
 func (article *ArticleService) ArticleBaseSearch(
 	params vo.BaseArticleSearchVo, id string,
 ) (ans *vo.BaseArticleSearchResultVo, err error) {
-	return article.ArticleDao.ArticleBaseSearch(params, id)
+	if params.Render != "" && params.Render != ArticleRenderHtml {
+		return nil, errors.New("ArticleBaseSearch: 不支持的渲染方式 " + params.Render)
+	}
+	// 渲染与摘要都需要md内容，懒加载在处理之后再生效
+	searching := params.SearchText != "" && id == ""
+	lazy := params.Lazy
+	if params.Render == ArticleRenderHtml || searching {
+		params.Lazy = false
+	}
+	if searching {
+		ans, err = article.searchArticles(params)
+	} else {
+		ans, err = article.ArticleDao.ArticleBaseSearch(params, id)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if params.Render == ArticleRenderHtml {
+		renderArticles(ans.Articles)
+	}
+	if searching {
+		highlightArticles(ans.Articles, params.SearchText)
+	}
+	if lazy {
+		for index := range ans.Articles {
+			ans.Articles[index].Markdown = ""
+		}
+	}
+	// 按id查询时带上系列的上下文
+	if id != "" && len(ans.Articles) == 1 {
+		current := &ans.Articles[0]
+		if current.Series, err = articleSeriesContext(article.SeriesDao, article.ArticleDao, current.Id.Hex()); err != nil {
+			return nil, err
+		}
+		// 相关文章只是附加信息，计算失败时不影响文章本身的返回
+		if current.Related, _, err = article.relatedArticles(current.Id.Hex(), RelatedSize()); err != nil {
+			global.Logger.Error(err)
+		}
+	}
+	return ans, nil
+}
+
+// AdminArticleList admin权限下按发布状态查看文章，用于管理草稿与定时发布的文章
+func (article *ArticleService) AdminArticleList(
+	params vo.AdminArticleListVo,
+) (*vo.BaseArticleSearchResultVo, error) {
+	return article.ArticleDao.AdminArticleList(params)
 }
 
 // ArticleInCategory 某一分类下的文章
@@ -148,20 +331,191 @@ func updateArticleMetaByParams(input *po.Article, params interface{}, uuid strin
 		input.ReadsNumber = 0
 		input.CommentsNumber = 0
 		input.PraiseNumber = 0
-		input.Tags = meta.Tags
-		input.Categories = meta.Categories
+		input.Tags = uniqueStringSlice(meta.Tags)
+		input.Categories = uniqueStringSlice(meta.Categories)
 		var curTime = time.Now()
 		input.UpdateTime = curTime
 		input.CreateTime = curTime
+		// 导入的文章保留原本的日期
+		if !meta.CreateTime.IsZero() {
+			input.CreateTime = meta.CreateTime
+			input.UpdateTime = meta.CreateTime
+		}
+		if !meta.UpdateTime.IsZero() {
+			input.UpdateTime = meta.UpdateTime
+		}
 		// 接下来“修补”模型的值
 		// input.Detail = utils.Markdown2Html(input.Markdown)
 		// input.ArtLength = len(input.Markdown)
-		wordCounter := utils.WordCounter{}
-		wordCounter.Stat(input.Markdown)
-		input.ArtLength = int64(wordCounter.Total)
+		input.ArtLength, input.ContentStat = articleContentStat(input.Markdown)
 		input.MdWords = utils.WordSplitForSearching(input.Markdown)
 		input.TitleWords = utils.WordSplitForSearching(input.Title)
+		input.PublishTime = meta.PublishTime
+		if input.PublishTime.IsZero() {
+			input.PublishTime = curTime
+			if !meta.CreateTime.IsZero() {
+				input.PublishTime = meta.CreateTime
+			}
+		}
+	}
+}
+
+// articlePublicVisible 文章是否对外可见：不在回收站、不是草稿、已到发布时间
+// 与dao中publicVisibleFilter的条件保持一致
+func articlePublicVisible(origin *po.Article) bool {
+	if origin.DeleteFlag || origin.DraftFlag {
+		return false
+	}
+	return origin.PublishTime.IsZero() || !origin.PublishTime.After(time.Now())
+}
+
+// existingArticle 指定的id已存在文章时返回该文章，否则返回nil
+func (article *ArticleService) existingArticle(id string) *po.Article {
+	if id == "" {
+		return nil
+	}
+	if origin, err := article.ArticleDao.FindArticleById(id); err == nil {
+		return origin
+	}
+	return nil
+}
+
+// overheadChanged 更新参数是否改变了文章实际的置顶状态
+func overheadChanged(origin *po.Article, overhead *bool) bool {
+	if overhead == nil {
+		return false
+	}
+	expired := !origin.OverheadExpire.IsZero() && !origin.OverheadExpire.After(time.Now())
+	active := origin.Overhead && !expired
+	return *overhead != active || (!*overhead && origin.Overhead)
+}
+
+// articleMetaToUpdateParams 将新增文章的参数转换为全量更新的参数
+func articleMetaToUpdateParams(meta vo.AdminArticleAddMetaVo, markdown string) vo.AdminArticleUpdateVo {
+	params := vo.AdminArticleUpdateVo{
+		Title:      &meta.Title,
+		Author:     &meta.Author,
+		Synopsis:   &meta.Synopsis,
+		Markdown:   &markdown,
+		Tags:       &meta.Tags,
+		Categories: &meta.Categories,
+		DraftFlag:  &meta.DraftFlag,
+		Overhead:   &meta.Overhead,
+		PicUrl:     &meta.PicUrl,
+	}
+	// 未指定slug时保留原有的slug，标题变更时会重新生成
+	if meta.Slug != "" {
+		params.Slug = &meta.Slug
+	}
+	// 未指定发布时间时保留原有的发布时间
+	if !meta.PublishTime.IsZero() {
+		params.PublishTime = &meta.PublishTime
+	}
+	return params
+}
+
+// patchArticleByParams 用局部更新的参数修补文章，只处理提供了的字段
+func patchArticleByParams(input *po.Article, params vo.AdminArticleUpdateVo) {
+	if params.Title != nil {
+		input.Title = *params.Title
+		input.TitleWords = utils.WordSplitForSearching(input.Title)
+	}
+	if params.Author != nil {
+		input.Author = *params.Author
+	}
+	if params.Synopsis != nil {
+		input.Synopsis = *params.Synopsis
+	}
+	if params.PicUrl != nil {
+		input.PicUrl = *params.PicUrl
+	}
+	if params.DraftFlag != nil {
+		input.DraftFlag = *params.DraftFlag
+	}
+	if params.Overhead != nil {
+		input.Overhead = *params.Overhead
+	}
+	if params.Tags != nil {
+		input.Tags = uniqueStringSlice(*params.Tags)
+	}
+	if params.Categories != nil {
+		input.Categories = uniqueStringSlice(*params.Categories)
+	}
+	if params.PublishTime != nil {
+		input.PublishTime = *params.PublishTime
+	}
+	if input.PublishTime.IsZero() {
+		// 旧数据没有发布时间，以创建时间补齐
+		input.PublishTime = input.CreateTime
+	}
+	if params.Markdown != nil {
+		input.Markdown = *params.Markdown
+		input.ArtLength, input.ContentStat = articleContentStat(input.Markdown)
+		input.MdWords = utils.WordSplitForSearching(input.Markdown)
+	}
+	input.UpdateTime = time.Now()
+}
+
+// BackfillContentStats 重新统计所有文章的内容，用于补齐旧文章或统计规则变化之后
+func (article *ArticleService) BackfillContentStats() (*vo.AdminArticleContentStatBackfillResultVo, error) {
+	result := &vo.AdminArticleContentStatBackfillResultVo{}
+	err := article.ArticleDao.EachArticleMarkdown(func(val *po.Article) error {
+		artLength, stat := articleContentStat(val.Markdown)
+		if err := article.ArticleDao.SetContentStat(val.Id, artLength, stat); err != nil {
+			return err
+		}
+		result.Count++
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return result, nil
+}
+
+// articleContentStat 统计md内容，返回文章长度与内容统计
+func articleContentStat(markdown string) (int64, po.ArticleContentStat) {
+	wordCounter := utils.WordCounter{}
+	wordCounter.Stat(markdown)
+	return int64(wordCounter.Total), po.ArticleContentStat{
+		Words:          int64(wordCounter.Words),
+		Puncts:         int64(wordCounter.Puncts),
+		CJKChars:       int64(wordCounter.CJKChars),
+		LatinWords:     int64(wordCounter.LatinWords),
+		Links:          int64(wordCounter.Links),
+		Pics:           int64(wordCounter.Pics),
+		CodeLines:      int64(wordCounter.CodeLines),
+		ReadingMinutes: int64(wordCounter.ReadingMinutes()),
+	}
+}
+
+// uniqueStringSlice 去除空串与重复项，保持原有顺序
+func uniqueStringSlice(items []string) []string {
+	result := make([]string, 0, len(items))
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	return result
+}
+
+// diffStringSlice 返回在a中但不在b中的元素
+func diffStringSlice(a, b []string) []string {
+	exists := make(map[string]bool, len(b))
+	for _, item := range b {
+		exists[item] = true
+	}
+	result := make([]string, 0)
+	for _, item := range a {
+		if !exists[item] {
+			result = append(result, item)
+		}
+	}
+	return result
 }
 
 // checkAndPatchArticleUuid 检查并修补文章uuid的值
@@ -184,21 +538,162 @@ func (article *ArticleService) checkAndPatchArticleUuid(uuid string) (string, er
 	return uuid, nil
 }
 
-// DeleteArticle 删除文章
+// DeleteArticle 删除文章，文章只是被移入回收站，分类的倒排在彻底删除时才清理
 func (article *ArticleService) DeleteArticle(id string) (int64, error) {
-	articleInfo, err := article.ArticleBaseSearch(vo.BaseArticleSearchVo{}, id)
+	origin, err := article.ArticleDao.FindArticleById(id)
 	if err != nil {
 		return 0, err
 	}
-	if articleInfo.TotalCount <= 0 {
-		return 0, nil
+	count, err := article.ArticleDao.TrashArticle(origin.Id)
+	if err == nil {
+		article.articlesChanged(origin.Id)
 	}
-	categories := articleInfo.Articles[0].Categories
-	if removeRes, err := article.CategoryDao.RemoveArticle(categories, id); err != nil {
-		fmt.Println(err)
+	return count, err
+}
+
+// RestoreArticle 将文章移出回收站
+func (article *ArticleService) RestoreArticle(id string) (int64, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
 		return 0, err
-	} else {
-		fmt.Println(removeRes)
 	}
-	return article.ArticleDao.DeleteArticle(id)
+	count, err := article.ArticleDao.RestoreArticle(origin.Id)
+	if err == nil {
+		article.articlesChanged(origin.Id)
+	}
+	return count, err
+}
+
+// ArticleOverhead 文章的置顶与取消置顶，到期时间必须晚于当前时间
+func (article *ArticleService) ArticleOverhead(
+	params vo.AdminArticleOverheadVo, id string,
+) (*vo.AdminArticleOverheadResultVo, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	if params.Overhead && params.ExpireTime != nil && !params.ExpireTime.IsZero() &&
+		!params.ExpireTime.After(time.Now()) {
+		return nil, errors.New("ArticleOverhead: 置顶的到期时间已经过去")
+	}
+	res, err := article.ArticleDao.SetOverhead(origin.Id, params.Overhead, params.Order, params.ExpireTime)
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.AdminArticleOverheadResultVo{
+		Id:            origin.Id,
+		Overhead:      params.Overhead,
+		ModifiedCount: res.ModifiedCount,
+	}
+	if params.Overhead {
+		result.Order = params.Order
+		if params.ExpireTime != nil && !params.ExpireTime.IsZero() {
+			result.ExpireTime = params.ExpireTime
+		}
+	}
+	return result, nil
+}
+
+// ArticleTrash 回收站中的文章
+func (article *ArticleService) ArticleTrash(params vo.BaseParams) (*vo.AdminArticleTrashResultVo, error) {
+	result, err := article.ArticleDao.TrashedArticles(params)
+	if err != nil {
+		return nil, err
+	}
+	retention := TrashRetention()
+	for index, val := range result.Articles {
+		result.Articles[index].UpdateTime = val.UpdateTime.Local()
+		result.Articles[index].DeleteTime = val.DeleteTime.Local()
+		result.Articles[index].PurgeTime = val.DeleteTime.Add(retention).Local()
+	}
+	return result, nil
+}
+
+// PurgeArticle 彻底删除回收站中的一篇文章
+func (article *ArticleService) PurgeArticle(id string) (int64, error) {
+	origin, err := article.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return 0, err
+	}
+	if !origin.DeleteFlag {
+		return 0, errors.New("PurgeArticle: 只能彻底删除回收站中的文章")
+	}
+	return article.purgeArticle(origin)
+}
+
+// PurgeExpiredTrash 彻底删除回收站中超过保留期的文章
+func (article *ArticleService) PurgeExpiredTrash(retention time.Duration) (int64, error) {
+	expired, err := article.ArticleDao.ExpiredTrashArticles(time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	var count int64
+	for index := range expired {
+		deleted, err := article.purgeArticle(&expired[index])
+		if err != nil {
+			return count, err
+		}
+		count += deleted
+	}
+	return count, nil
+}
+
+// PurgeTrashPeriodically 定期清理回收站，阻塞运行，需要在单独的goroutine中调用
+func (article *ArticleService) PurgeTrashPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if count, err := article.PurgeExpiredTrash(TrashRetention()); err != nil {
+			global.Logger.Errorf("清理回收站失败: %v", err)
+		} else if count > 0 {
+			global.Logger.Infof("清理回收站: 彻底删除%d篇文章", count)
+		}
+		<-ticker.C
+	}
+}
+
+// purgeArticle 清理分类的倒排与历史版本，再彻底删除文章
+func (article *ArticleService) purgeArticle(origin *po.Article) (int64, error) {
+	id := origin.Id.Hex()
+	if len(origin.Categories) > 0 {
+		if _, err := article.CategoryDao.RemoveArticle(origin.Categories, id); err != nil {
+			return 0, err
+		}
+	}
+	if _, err := article.ArticleRevisionDao.DeleteRevisions(origin.Id); err != nil {
+		return 0, err
+	}
+	if _, err := article.CommentDao.DeleteArticleComments(origin.Id); err != nil {
+		return 0, err
+	}
+	if _, err := article.SeriesDao.RemoveArticle(id); err != nil {
+		return 0, err
+	}
+	if _, err := article.ArticlePraiseDao.DeleteArticlePraises(origin.Id); err != nil {
+		return 0, err
+	}
+	if _, err := article.ArticleStatDao.DeleteArticleStats(origin.Id); err != nil {
+		return 0, err
+	}
+	count, err := article.ArticleDao.DeleteArticle(id)
+	if err == nil {
+		article.articlesChanged(origin.Id)
+	}
+	return count, err
+}
+
+// articlesChanged 文章新增、修改、删除之后调用，使相关文章与热门文章的缓存失效并更新全文索引
+func (article *ArticleService) articlesChanged(ids ...primitive.ObjectID) {
+	invalidateRelatedArticles()
+	invalidateHotArticles()
+	article.reindexArticles(ids...)
+}
+
+// TrashRetention 回收站的保留时长
+func TrashRetention() time.Duration {
+	days := 30
+	if global.Config != nil && global.Config.Article.TrashRetentionDays > 0 {
+		days = global.Config.Article.TrashRetentionDays
+	}
+	return time.Duration(days) * 24 * time.Hour
 }
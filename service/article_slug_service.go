@@ -0,0 +1,126 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章的slug，标题变更之后旧的slug仍然可以访问并重定向到新的slug
+ * @File:  article_slug_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"strconv"
+	"strings"
+)
+
+// defaultSlug 标题无法转写时使用的slug
+const defaultSlug = "article"
+
+// ArticleBySlug 通过slug获取文章，命中旧的slug时返回当前的slug，由调用方重定向
+func (article *ArticleService) ArticleBySlug(
+	slug string, params vo.BaseArticleSearchVo,
+) (*vo.BaseArticleSearchResultVo, string, error) {
+	origin, err := article.ArticleDao.FindArticleBySlug(strings.ToLower(strings.TrimSpace(slug)))
+	if err != nil {
+		return nil, "", err
+	}
+	if origin.Slug != slug {
+		return nil, origin.Slug, nil
+	}
+	ans, err := article.ArticleBaseSearch(params, origin.Id.Hex())
+	if err != nil {
+		return nil, "", err
+	}
+	// 查找之后到查询详情之间文章可能刚好变为不可见
+	if len(ans.Articles) == 0 {
+		return nil, "", errors.New("ArticleBySlug: 文章不存在")
+	}
+	return ans, "", nil
+}
+
+// BackfillSlugs 为还没有slug的旧文章生成slug
+func (article *ArticleService) BackfillSlugs() (*vo.AdminArticleSlugBackfillResultVo, error) {
+	articles, err := article.ArticleDao.ArticlesWithoutSlug()
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.AdminArticleSlugBackfillResultVo{Slugs: map[string]string{}}
+	for _, val := range articles {
+		slug, err := article.uniqueSlug(utils.Slugify(val.Title), val.Id)
+		if err != nil {
+			return nil, err
+		}
+		if err = article.ArticleDao.SetSlug(val.Id, slug); err != nil {
+			return nil, err
+		}
+		result.Slugs[val.Id.Hex()] = slug
+	}
+	if result.Count = int64(len(result.Slugs)); result.Count > 0 {
+		invalidateRelatedArticles()
+		invalidateHotArticles()
+	}
+	return result, nil
+}
+
+// newArticleSlug 新文章的slug，优先使用自定义的slug
+func (article *ArticleService) newArticleSlug(input *po.Article, custom string) (string, error) {
+	base := utils.Slugify(custom)
+	if base == "" {
+		base = utils.Slugify(input.Title)
+	}
+	return article.uniqueSlug(base, input.Id)
+}
+
+// assignArticleSlug 更新文章时维护slug：指定了slug、标题变更或旧文章没有slug时重新生成
+// 新的slug与原来不同时，原来的slug进入历史，保证旧链接仍然可以访问
+func (article *ArticleService) assignArticleSlug(input, origin *po.Article, custom *string) error {
+	var base string
+	switch {
+	case custom != nil && utils.Slugify(*custom) != "":
+		base = utils.Slugify(*custom)
+	case custom != nil || input.Title != origin.Title || origin.Slug == "":
+		base = utils.Slugify(input.Title)
+	default:
+		return nil
+	}
+	slug, err := article.uniqueSlug(base, input.Id)
+	if err != nil {
+		return err
+	}
+	if slug == origin.Slug {
+		return nil
+	}
+	history := make([]string, 0, len(origin.SlugHistory)+1)
+	for _, val := range append(origin.SlugHistory, origin.Slug) {
+		if val != "" && val != slug {
+			history = append(history, val)
+		}
+	}
+	input.Slug = slug
+	input.SlugHistory = uniqueStringSlice(history)
+	return nil
+}
+
+// uniqueSlug 在base的基础上依次尝试 -2、-3 后缀，直到没有被其他文章使用
+func (article *ArticleService) uniqueSlug(base string, self primitive.ObjectID) (string, error) {
+	if base == "" {
+		base = defaultSlug
+	}
+	slug := base
+	for index := 2; ; index++ {
+		taken, err := article.ArticleDao.SlugTaken(slug, self)
+		if err != nil {
+			return "", err
+		}
+		if !taken {
+			return slug, nil
+		}
+		slug = base + "-" + strconv.Itoa(index)
+	}
+}
@@ -0,0 +1,232 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章的每日统计：阅读数的时间序列、来源、前台路径与设备分布，以及近期的阅读排行
+ * 	统计由计数器在计入阅读时累积，见article_counter_service
+ * @File:  article_stat_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/url"
+	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// articleStatsMaxDays 一次查询的最大天数
+	articleStatsMaxDays = 366
+	// articleStatsTopSize 来源与路径只返回数量最多的若干项
+	articleStatsTopSize = 20
+	// articleStatsMaxReferrers 一个分桶中最多记录的来源数，重启之后重新计算
+	articleStatsMaxReferrers = 100
+	// 来源的特殊取值
+	referrerDirect   = "direct"
+	referrerInternal = "internal"
+	// statOther 超过上限的来源与无法识别的前台路径
+	statOther = "other"
+)
+
+// ArticleStats 日期范围内的统计，id为空时为全站的统计
+func (article *ArticleService) ArticleStats(params vo.AdminArticleStatsVo, id string) (*vo.AdminArticleStatsResultVo, error) {
+	start, end, err := articleStatsRange(params.Start, params.End)
+	if err != nil {
+		return nil, err
+	}
+	var articleId *primitive.ObjectID
+	if id != "" {
+		origin, err := article.ArticleDao.FindArticleById(id)
+		if err != nil {
+			return nil, err
+		}
+		articleId = &origin.Id
+	}
+	// 查询到结束日期的下一天零点为止
+	stats, err := article.ArticleStatDao.ArticleStatsBetween(start, end.AddDate(0, 0, 1), articleId)
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.AdminArticleStatsResultVo{
+		Start: start.Format("2006-01-02"),
+		End:   end.Format("2006-01-02"),
+		Days:  []vo.ArticleStatsDayVo{},
+	}
+	if articleId != nil {
+		result.ArticleId = articleId.Hex()
+	}
+	views := map[string]int64{}
+	referrers, paths, devices, browsers := map[string]int64{}, map[string]int64{}, map[string]int64{}, map[string]int64{}
+	for _, val := range stats {
+		views[val.Day] += val.Views
+		result.Views += val.Views
+		for _, pair := range [][2]map[string]int64{
+			{referrers, val.Referrers}, {paths, val.Paths}, {devices, val.Devices}, {browsers, val.Browsers},
+		} {
+			for name, count := range pair[1] {
+				pair[0][name] += count
+			}
+		}
+	}
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		date := day.Format("2006-01-02")
+		result.Days = append(result.Days, vo.ArticleStatsDayVo{Date: date, Views: views[date]})
+	}
+	result.Referrers = articleStatsCounts(referrers, articleStatsTopSize)
+	result.Paths = articleStatsCounts(paths, articleStatsTopSize)
+	result.Devices = articleStatsCounts(devices, 0)
+	result.Browsers = articleStatsCounts(browsers, 0)
+	return result, nil
+}
+
+// ArticleTop 包含今天在内最近若干天阅读数最多的文章
+func (article *ArticleService) ArticleTop(params vo.AdminArticleTopVo) (*vo.AdminArticleTopResultVo, error) {
+	days, size := params.Days, params.Size
+	if days <= 0 {
+		days = 7
+	}
+	if days > articleStatsMaxDays {
+		return nil, errors.New("ArticleTop: 天数不能超过" + strconv.Itoa(articleStatsMaxDays))
+	}
+	if size <= 0 {
+		size = 10
+	}
+	if size > 100 {
+		size = 100
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := today.AddDate(0, 0, -int(days-1))
+	items, err := article.ArticleStatDao.TopArticlesBetween(start, today.AddDate(0, 0, 1), size)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(items))
+	for _, val := range items {
+		ids = append(ids, val.Id.Hex())
+	}
+	briefs, err := article.ArticleDao.ArticlesBrief(ids)
+	if err != nil {
+		return nil, err
+	}
+	briefOf := make(map[primitive.ObjectID]po.Article, len(briefs))
+	for _, val := range briefs {
+		briefOf[val.Id] = val
+	}
+	// 已经彻底删除的文章不出现在排行中
+	articles := make([]vo.AdminArticleTopItemVo, 0, len(items))
+	for _, val := range items {
+		if brief, ok := briefOf[val.Id]; ok {
+			val.Title, val.Slug = brief.Title, brief.Slug
+			articles = append(articles, val)
+		}
+	}
+	return &vo.AdminArticleTopResultVo{
+		Start: start.Format("2006-01-02"), End: today.Format("2006-01-02"), Articles: articles,
+	}, nil
+}
+
+// articleStatsRange 解析日期范围，返回开始与结束日期的零点
+func articleStatsRange(startText, endText string) (time.Time, time.Time, error) {
+	now := time.Now()
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var err error
+	if endText != "" {
+		if end, err = time.ParseInLocation("2006-01-02", endText, time.Local); err != nil {
+			return time.Time{}, time.Time{}, errors.New("ArticleStats: 结束日期的格式应为2006-01-02")
+		}
+	}
+	start := end.AddDate(0, 0, -29)
+	if startText != "" {
+		if start, err = time.ParseInLocation("2006-01-02", startText, time.Local); err != nil {
+			return time.Time{}, time.Time{}, errors.New("ArticleStats: 开始日期的格式应为2006-01-02")
+		}
+	}
+	if start.After(end) {
+		return time.Time{}, time.Time{}, errors.New("ArticleStats: 开始日期不能晚于结束日期")
+	}
+	if end.Sub(start) >= articleStatsMaxDays*24*time.Hour {
+		return time.Time{}, time.Time{}, errors.New("ArticleStats: 日期范围不能超过" + strconv.Itoa(articleStatsMaxDays) + "天")
+	}
+	return start, end, nil
+}
+
+// articleStatsCounts 按数量倒序排列分布，limit大于0时只保留前limit项
+func articleStatsCounts(counts map[string]int64, limit int) []vo.ArticleStatsCountVo {
+	result := make([]vo.ArticleStatsCountVo, 0, len(counts))
+	for name, count := range counts {
+		result = append(result, vo.ArticleStatsCountVo{Name: name, Count: count})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Name < result[j].Name
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// visitReferrer 来源站点的域名，没有来源时为direct，来自本站时为internal
+// 本站为配置的站点地址，未配置时为请求的来源站点；阅读的页面由客户端提供，不用于判断
+func visitReferrer(visit bo.ArticleVisit) string {
+	referrer := strings.TrimSpace(visit.Referrer)
+	if referrer == "" {
+		return referrerDirect
+	}
+	host := urlHost(referrer)
+	if host == "" {
+		return referrerDirect
+	}
+	if host == urlHost(SiteUrl(visit.Origin)) {
+		return referrerInternal
+	}
+	return host
+}
+
+// urlHost 地址中去掉www.的小写域名，无法解析时为空
+func urlHost(address string) string {
+	parsed, err := url.Parse(address)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// visitPage 阅读文章的前台路径，可以是完整的地址或路径，忽略查询参数与锚点
+// 只记录文章自身按id或slug的前台路径，其余的路径由客户端随意填写，计入other
+func visitPage(page string, id string, slug string) string {
+	page = strings.TrimSpace(page)
+	if page == "" {
+		return ""
+	}
+	parsed, err := url.Parse(page)
+	if err != nil {
+		return statOther
+	}
+	path := strings.TrimSuffix(parsed.Path, "/")
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	known := []string{ArticleLink("", id)}
+	if slug != "" {
+		known = append(known, ArticleLink("", slug))
+	}
+	for _, val := range known {
+		if path == strings.TrimSuffix(val, "/") {
+			return val
+		}
+	}
+	return statOther
+}
@@ -0,0 +1,81 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章标签的统计、按标签查询以及标签的重命名与合并
+ * @File:  article_tag_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"r0Website-server/models/vo"
+	"strings"
+)
+
+// ArticleTags 对外可见文章的标签及其文章数
+func (article *ArticleService) ArticleTags() (*vo.ArticleTagListVo, error) {
+	return article.articleTags(true)
+}
+
+// AdminArticleTags 回收站以外文章的标签及其文章数，包含草稿与定时发布的文章
+func (article *ArticleService) AdminArticleTags() (*vo.ArticleTagListVo, error) {
+	return article.articleTags(false)
+}
+
+func (article *ArticleService) articleTags(publicOnly bool) (*vo.ArticleTagListVo, error) {
+	tags, err := article.ArticleDao.ArticleTagStats(publicOnly)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.ArticleTagListVo{Tags: tags, TotalCount: int64(len(tags))}, nil
+}
+
+// ArticleInTag 某一标签下的文章
+func (article *ArticleService) ArticleInTag(
+	params vo.ArticleSearchByTagVo,
+) (*vo.BaseArticleSearchResultVo, error) {
+	params.Tag = strings.TrimSpace(params.Tag)
+	if params.Tag == "" {
+		return nil, errors.New("ArticleInTag: 标签不能为空")
+	}
+	return article.ArticleDao.ArticleInTag(params)
+}
+
+// RenameArticleTag 重命名标签，新的标签已存在时等同于合并
+func (article *ArticleService) RenameArticleTag(
+	tag string, params vo.AdminArticleTagRenameVo,
+) (*vo.AdminArticleTagResultVo, error) {
+	return article.MergeArticleTags(vo.AdminArticleTagMergeVo{From: []string{tag}, To: params.Name})
+}
+
+// MergeArticleTags 将若干标签合并为一个，只修改标签不影响文章的更新时间
+func (article *ArticleService) MergeArticleTags(
+	params vo.AdminArticleTagMergeVo,
+) (*vo.AdminArticleTagResultVo, error) {
+	to := strings.TrimSpace(params.To)
+	if to == "" {
+		return nil, errors.New("MergeArticleTags: 目标标签不能为空")
+	}
+	from := make([]string, 0, len(params.From))
+	for _, tag := range uniqueStringSlice(params.From) {
+		if tag = strings.TrimSpace(tag); tag != "" && tag != to {
+			from = append(from, tag)
+		}
+	}
+	if len(from) == 0 {
+		return nil, errors.New("MergeArticleTags: 没有需要合并的标签")
+	}
+	affected, err := article.ArticleDao.ArticleIdsWithTags(from)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := article.ArticleDao.MergeArticleTags(from, to)
+	if err != nil {
+		return nil, err
+	}
+	article.articlesChanged(affected...)
+	return &vo.AdminArticleTagResultVo{From: from, To: to, ModifiedCount: modified}, nil
+}
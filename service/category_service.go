@@ -1,14 +1,22 @@
 package service
 
 import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"r0Website-server/dao"
 	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
 	"r0Website-server/models/vo"
+	"sort"
+	"strings"
 )
 
 type CategoryService struct {
 	CategoryDao *dao.CategoryDao `R0Ioc:"true"`
+	ArticleDao  *dao.ArticleDao  `R0Ioc:"true"`
+	// 修改文章中的分类之后与合并标签一样通过articlesChanged失效缓存
+	ArticleService *ArticleService `R0Ioc:"true"`
 }
 
 // All 所有分类
@@ -26,3 +34,239 @@ func (cs *CategoryService) ArchiveArticle(articleId, categoryName string) (*mong
 	}
 	return cs.CategoryDao.ArchiveArticle(articleId, categoryName)
 }
+
+// Create 新建分类
+func (cs *CategoryService) Create(params vo.AdminCategoryCreateVo) (*po.Category, error) {
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		return nil, &bo.NullError{NullField: "name"}
+	}
+	if exists, err := cs.CategoryDao.ExistsCategory(name); err != nil {
+		return nil, err
+	} else if exists {
+		return nil, errors.New("CreateCategory: 分类已存在")
+	}
+	input := &po.Category{
+		Name:        name,
+		ArticleIds:  []string{},
+		Description: params.Description,
+		Cover:       params.Cover,
+		SortOrder:   params.SortOrder,
+	}
+	if _, err := cs.CategoryDao.CreateCategory(input); err != nil {
+		return nil, err
+	}
+	// 可能已有文章使用了这个分类名
+	if err := cs.rebuildCategory(name); err != nil {
+		return nil, err
+	}
+	return cs.CategoryDao.CategorySearch(name)
+}
+
+// Update 修改分类的描述、封面与排序序号，名称变化时重命名并同步修改文章中的分类
+// 新的名称已存在时不会合并，需要使用Merge
+func (cs *CategoryService) Update(name string, params vo.AdminCategoryUpdateVo) (*vo.AdminCategoryResultVo, error) {
+	origin, err := cs.existingCategory(name)
+	if err != nil {
+		return nil, err
+	}
+	set := bson.M{}
+	if params.Description != nil {
+		set["description"] = *params.Description
+	}
+	if params.Cover != nil {
+		set["cover"] = *params.Cover
+	}
+	if params.SortOrder != nil {
+		set["sort_order"] = *params.SortOrder
+	}
+	to := origin.Name
+	if params.Name != nil {
+		if to = strings.TrimSpace(*params.Name); to == "" {
+			return nil, &bo.NullError{NullField: "name"}
+		}
+		if to != origin.Name {
+			if exists, err := cs.CategoryDao.ExistsCategory(to); err != nil {
+				return nil, err
+			} else if exists {
+				return nil, errors.New("UpdateCategory: 分类" + to + "已存在，请使用合并")
+			}
+			set["name"] = to
+		}
+	}
+	result := &vo.AdminCategoryResultVo{From: []string{origin.Name}, To: to}
+	if len(set) == 0 {
+		return result, nil
+	}
+	if _, err = cs.CategoryDao.UpdateCategory(origin.Name, set); err != nil {
+		return nil, err
+	}
+	if to != origin.Name {
+		affected, err := cs.ArticleDao.ArticleIdsWithCategories([]string{origin.Name})
+		if err != nil {
+			return nil, err
+		}
+		if result.ModifiedCount, err = cs.ArticleDao.MergeArticleCategories([]string{origin.Name}, to); err != nil {
+			return nil, err
+		}
+		cs.ArticleService.articlesChanged(affected...)
+	}
+	return result, nil
+}
+
+// Merge 将若干分类合并为一个，目标分类不存在时新建，被合并的分类会被删除
+func (cs *CategoryService) Merge(params vo.AdminCategoryMergeVo) (*vo.AdminCategoryResultVo, error) {
+	to := strings.TrimSpace(params.To)
+	if to == "" {
+		return nil, errors.New("MergeCategory: 目标分类不能为空")
+	}
+	from := make([]string, 0, len(params.From))
+	for _, name := range uniqueStringSlice(params.From) {
+		if name = strings.TrimSpace(name); name != "" && name != to {
+			from = append(from, name)
+		}
+	}
+	if len(from) == 0 {
+		return nil, errors.New("MergeCategory: 没有需要合并的分类")
+	}
+	return cs.mergeCategories(from, to)
+}
+
+// Delete 删除分类，指定to时文章改为归入to，否则只从文章中移除该分类
+func (cs *CategoryService) Delete(name string, params vo.AdminCategoryDeleteVo) (*vo.AdminCategoryResultVo, error) {
+	origin, err := cs.existingCategory(name)
+	if err != nil {
+		return nil, err
+	}
+	to := strings.TrimSpace(params.To)
+	if to == origin.Name {
+		return nil, errors.New("DeleteCategory: 不能归入被删除的分类")
+	}
+	if to != "" {
+		return cs.mergeCategories([]string{origin.Name}, to)
+	}
+	result := &vo.AdminCategoryResultVo{From: []string{origin.Name}}
+	affected, err := cs.ArticleDao.ArticleIdsWithCategories(result.From)
+	if err != nil {
+		return nil, err
+	}
+	if result.ModifiedCount, err = cs.ArticleDao.RemoveArticleCategory(origin.Name); err != nil {
+		return nil, err
+	}
+	if _, err = cs.CategoryDao.DeleteCategories(result.From); err != nil {
+		return nil, err
+	}
+	cs.ArticleService.articlesChanged(affected...)
+	return result, nil
+}
+
+// Repair 由文章重新统计每个分类的文章id与文章数，补建文章中存在但缺少记录的分类
+// 没有文章的分类会保留
+func (cs *CategoryService) Repair() (*vo.AdminCategoryRepairResultVo, error) {
+	articleIds, err := cs.ArticleDao.CategoryArticleIds()
+	if err != nil {
+		return nil, err
+	}
+	categories, err := cs.CategoryDao.AllCategories()
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.AdminCategoryRepairResultVo{Fixed: []string{}, Created: []string{}}
+	existing := make(map[string]bool, len(categories.Categories))
+	for _, val := range categories.Categories {
+		ids := articleIds[val.Name]
+		if ids == nil {
+			ids = []string{}
+		}
+		if !existing[val.Name] && (val.Count != int64(len(ids)) || !sameStringSet(val.ArticleIds, ids)) {
+			if err = cs.CategoryDao.SetCategoryArticles(val.Name, ids); err != nil {
+				return nil, err
+			}
+			result.Fixed = append(result.Fixed, val.Name)
+		}
+		existing[val.Name] = true
+	}
+	for name, ids := range articleIds {
+		if existing[name] {
+			continue
+		}
+		if _, err = cs.CategoryDao.CreateCategory(&po.Category{Name: name, Count: int64(len(ids)), ArticleIds: ids}); err != nil {
+			return nil, err
+		}
+		existing[name] = true
+		result.Created = append(result.Created, name)
+	}
+	sort.Strings(result.Created)
+	result.TotalCount = int64(len(existing))
+	return result, nil
+}
+
+// mergeCategories 将文章中的from分类改为to，重新统计to的文章，再删除from
+func (cs *CategoryService) mergeCategories(from []string, to string) (*vo.AdminCategoryResultVo, error) {
+	if exists, err := cs.CategoryDao.ExistsCategory(to); err != nil {
+		return nil, err
+	} else if !exists {
+		if _, err = cs.CategoryDao.AddCategory(to); err != nil {
+			return nil, err
+		}
+	}
+	affected, err := cs.ArticleDao.ArticleIdsWithCategories(from)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := cs.ArticleDao.MergeArticleCategories(from, to)
+	if err != nil {
+		return nil, err
+	}
+	if err = cs.rebuildCategory(to); err != nil {
+		return nil, err
+	}
+	if _, err = cs.CategoryDao.DeleteCategories(from); err != nil {
+		return nil, err
+	}
+	cs.ArticleService.articlesChanged(affected...)
+	return &vo.AdminCategoryResultVo{From: from, To: to, ModifiedCount: modified}, nil
+}
+
+// rebuildCategory 由文章重新统计一个分类的文章id与文章数
+func (cs *CategoryService) rebuildCategory(name string) error {
+	ids, err := cs.ArticleDao.ArticleIdsWithCategories([]string{name})
+	if err != nil {
+		return err
+	}
+	articleIds := make([]string, len(ids))
+	for index, id := range ids {
+		articleIds[index] = id.Hex()
+	}
+	return cs.CategoryDao.SetCategoryArticles(name, articleIds)
+}
+
+// existingCategory 按名称查找分类，不存在时返回错误
+func (cs *CategoryService) existingCategory(name string) (*po.Category, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, &bo.NullError{NullField: "name"}
+	}
+	category, err := cs.CategoryDao.CategorySearch(name)
+	if err != nil {
+		return nil, err
+	}
+	if category.Id.IsZero() {
+		return nil, errors.New("Category: 分类" + name + "不存在")
+	}
+	return category, nil
+}
+
+// sameStringSet 两个列表是否由相同的元素组成，重复的元素视为不一致
+func sameStringSet(a, b []string) bool {
+	setA, setB := stringSet(a), stringSet(b)
+	if len(setA) != len(setB) || len(a) != len(b) {
+		return false
+	}
+	for item := range setA {
+		if !setB[item] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,269 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章评论功能，访客评论需要审核之后才对外可见
+ * @File:  comment_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"net/url"
+	"r0Website-server/dao"
+	"r0Website-server/models/bo"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	commentMaxNameLength    = 32   // 昵称的最大长度
+	commentMaxWebsiteLength = 256  // 网站的最大长度
+	commentMaxContentLength = 2000 // 评论内容的最大长度
+)
+
+type CommentService struct {
+	CommentDao *dao.CommentDao `R0Ioc:"true"`
+	ArticleDao *dao.ArticleDao `R0Ioc:"true"`
+}
+
+// CommentList 文章下通过审核的评论，按顶层评论分页，回复平铺在所在楼层下
+func (cs *CommentService) CommentList(articleId string, params vo.BaseParams) (*vo.BaseCommentListResultVo, error) {
+	origin, err := cs.visibleArticle(articleId)
+	if err != nil {
+		return nil, err
+	}
+	pageNumber, pageSize := params.PageNumber, params.PageSize
+	roots, total, err := cs.CommentDao.ApprovedRootComments(origin.Id, &pageNumber, &pageSize)
+	if err != nil {
+		return nil, err
+	}
+	rootIds := make([]primitive.ObjectID, len(roots))
+	for index, val := range roots {
+		rootIds[index] = val.Id
+	}
+	replies, err := cs.CommentDao.ApprovedReplies(rootIds)
+	if err != nil {
+		return nil, err
+	}
+	// 昵称用于展示"回复 @xxx"，被回复的评论未通过审核时为空
+	names := make(map[primitive.ObjectID]string, len(roots)+len(replies))
+	for _, val := range roots {
+		names[val.Id] = val.Name
+	}
+	for _, val := range replies {
+		names[val.Id] = val.Name
+	}
+	repliesOfRoot := make(map[primitive.ObjectID][]vo.BaseCommentVo, len(roots))
+	for _, val := range replies {
+		reply := commentToBaseVo(&val)
+		reply.ReplyTo = names[val.ParentId]
+		repliesOfRoot[val.RootId] = append(repliesOfRoot[val.RootId], reply)
+	}
+	result := &vo.BaseCommentListResultVo{
+		Comments:   make([]vo.BaseCommentVo, len(roots)),
+		PageNumber: pageNumber,
+		PageSize:   pageSize,
+		AnsCount:   int64(len(roots)),
+		TotalCount: total,
+		Count:      origin.CommentsNumber,
+	}
+	for index, val := range roots {
+		result.Comments[index] = commentToBaseVo(&val)
+		if list, ok := repliesOfRoot[val.Id]; ok {
+			result.Comments[index].Replies = list
+		}
+	}
+	return result, nil
+}
+
+// AddComment 访客发表评论，新评论进入待审核队列
+func (cs *CommentService) AddComment(
+	params vo.BaseCommentAddVo, articleId, ip, userAgent string,
+) (*vo.BaseCommentAddResultVo, error) {
+	origin, err := cs.visibleArticle(articleId)
+	if err != nil {
+		return nil, err
+	}
+	comment, err := newCommentByParams(params)
+	if err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(params.ParentId) != "" {
+		parent, err := cs.CommentDao.FindCommentById(strings.TrimSpace(params.ParentId))
+		if err != nil {
+			return nil, err
+		}
+		if parent.ArticleId != origin.Id || parent.Status != po.CommentStatusApproved {
+			return nil, errors.New("AddComment: 回复的评论不存在")
+		}
+		comment.ParentId = parent.Id
+		comment.RootId = parent.RootId
+		if comment.RootId.IsZero() {
+			comment.RootId = parent.Id
+		}
+	}
+	now := time.Now()
+	comment.ArticleId = origin.Id
+	comment.Status = po.CommentStatusPending
+	comment.Ip = ip
+	comment.UserAgent = userAgent
+	comment.CreateTime = now
+	comment.UpdateTime = now
+	if err = cs.CommentDao.CreateComment(comment); err != nil {
+		return nil, err
+	}
+	return &vo.BaseCommentAddResultVo{Id: comment.Id, Status: comment.Status}, nil
+}
+
+// AdminCommentList 管理员查看评论，可按审核状态与文章过滤
+func (cs *CommentService) AdminCommentList(params vo.AdminCommentListVo) (*vo.AdminCommentListResultVo, error) {
+	if params.Status != "" && !validCommentStatus(params.Status) {
+		return nil, errors.New("AdminCommentList: 未知的评论状态 " + params.Status)
+	}
+	return cs.CommentDao.AdminCommentList(params)
+}
+
+// ApproveComment 评论通过审核
+func (cs *CommentService) ApproveComment(id string) (*vo.AdminCommentResultVo, error) {
+	return cs.setCommentStatus(id, po.CommentStatusApproved)
+}
+
+// RejectComment 拒绝评论
+func (cs *CommentService) RejectComment(id string) (*vo.AdminCommentResultVo, error) {
+	return cs.setCommentStatus(id, po.CommentStatusRejected)
+}
+
+// SpamComment 标记为垃圾评论
+func (cs *CommentService) SpamComment(id string) (*vo.AdminCommentResultVo, error) {
+	return cs.setCommentStatus(id, po.CommentStatusSpam)
+}
+
+// DeleteComment 删除评论，回复了它的评论一并删除
+func (cs *CommentService) DeleteComment(id string) (*vo.AdminCommentResultVo, error) {
+	comment, err := cs.CommentDao.FindCommentById(id)
+	if err != nil {
+		return nil, err
+	}
+	ids := []primitive.ObjectID{comment.Id}
+	frontier := ids
+	for len(frontier) > 0 {
+		if frontier, err = cs.CommentDao.ChildCommentIds(frontier); err != nil {
+			return nil, err
+		}
+		ids = append(ids, frontier...)
+	}
+	count, err := cs.CommentDao.DeleteComments(ids)
+	if err != nil {
+		return nil, err
+	}
+	commentsNumber, err := cs.syncCommentsNumber(comment.ArticleId)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.AdminCommentResultVo{Id: comment.Id, ModifiedCount: count, CommentsNumber: commentsNumber}, nil
+}
+
+// setCommentStatus 修改评论的审核状态，并重新统计文章的评论数
+func (cs *CommentService) setCommentStatus(id, status string) (*vo.AdminCommentResultVo, error) {
+	comment, err := cs.CommentDao.FindCommentById(id)
+	if err != nil {
+		return nil, err
+	}
+	count, err := cs.CommentDao.SetCommentStatus(comment.Id, status)
+	if err != nil {
+		return nil, err
+	}
+	commentsNumber, err := cs.syncCommentsNumber(comment.ArticleId)
+	if err != nil {
+		return nil, err
+	}
+	return &vo.AdminCommentResultVo{
+		Id: comment.Id, Status: status, ModifiedCount: count, CommentsNumber: commentsNumber,
+	}, nil
+}
+
+// syncCommentsNumber 重新统计文章通过审核的评论数并写回文章
+// 直接统计而不是增减，重复审核或并发审核都不会让计数漂移
+func (cs *CommentService) syncCommentsNumber(articleId primitive.ObjectID) (int64, error) {
+	count, err := cs.CommentDao.CountApproved(articleId)
+	if err != nil {
+		return 0, err
+	}
+	if err = cs.ArticleDao.SetCommentsNumber(articleId, count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// visibleArticle 查找对外可见的文章，草稿、定时发布与回收站中的文章视为不存在
+func (cs *CommentService) visibleArticle(id string) (*po.Article, error) {
+	origin, err := cs.ArticleDao.FindArticleById(id)
+	if err != nil {
+		return nil, err
+	}
+	if !articlePublicVisible(origin) {
+		return nil, errors.New("FindArticleById: 文章不存在")
+	}
+	return origin, nil
+}
+
+// newCommentByParams 校验访客提交的内容并构造评论
+func newCommentByParams(params vo.BaseCommentAddVo) (*po.Comment, error) {
+	name := strings.TrimSpace(params.Name)
+	content := strings.TrimSpace(params.Content)
+	website := strings.TrimSpace(params.Website)
+	if name == "" {
+		return nil, &bo.NullError{NullField: "name"}
+	}
+	if content == "" {
+		return nil, &bo.NullError{NullField: "content"}
+	}
+	if utf8.RuneCountInString(name) > commentMaxNameLength {
+		return nil, errors.New("AddComment: 昵称过长")
+	}
+	if utf8.RuneCountInString(content) > commentMaxContentLength {
+		return nil, errors.New("AddComment: 评论内容过长")
+	}
+	if website != "" {
+		link, err := url.Parse(website)
+		if err != nil || (link.Scheme != "http" && link.Scheme != "https") || link.Host == "" ||
+			len(website) > commentMaxWebsiteLength {
+			return nil, errors.New("AddComment: 网站需要是http(s)开头的链接")
+		}
+	}
+	return &po.Comment{
+		Name:    name,
+		Email:   strings.ToLower(strings.TrimSpace(params.Email)),
+		Website: website,
+		Content: content,
+	}, nil
+}
+
+// validCommentStatus 是否为已知的评论状态
+func validCommentStatus(status string) bool {
+	switch status {
+	case po.CommentStatusPending, po.CommentStatusApproved, po.CommentStatusRejected, po.CommentStatusSpam:
+		return true
+	}
+	return false
+}
+
+// commentToBaseVo 转换为对外展示的评论
+func commentToBaseVo(comment *po.Comment) vo.BaseCommentVo {
+	return vo.BaseCommentVo{
+		Id:         comment.Id,
+		ParentId:   comment.ParentId,
+		Name:       comment.Name,
+		Website:    comment.Website,
+		Content:    comment.Content,
+		CreateTime: comment.CreateTime.Local(),
+		Replies:    []vo.BaseCommentVo{},
+	}
+}
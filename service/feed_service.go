@@ -0,0 +1,290 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 订阅源，RSS 2.0、Atom与JSON Feed共用同一批已发布的文章
+ * @File:  feed_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/url"
+	"r0Website-server/config"
+	"r0Website-server/dao"
+	"r0Website-server/global"
+	"r0Website-server/models/bo"
+	"r0Website-server/models/vo"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultFeedSize          = 20
+	defaultArticlePath       = "/article/{id}"
+	defaultCategoryPath      = "/category/{name}"
+	defaultAlbumPath         = "/album/{id}"
+	defaultImageCategoryPath = "/gallery/{id}"
+)
+
+type FeedService struct {
+	ArticleDao *dao.ArticleDao `R0Ioc:"true"`
+}
+
+// FeedSource 生成订阅源所需的数据
+type FeedSource struct {
+	Title        string                               // 订阅源标题，分类订阅时带上分类名
+	Site         string                               // 站点地址，用于生成文章链接
+	Link         string                               // 站点首页
+	Description  string                               // 站点描述
+	SelfUrl      string                               // 订阅源自身的地址
+	Articles     []vo.SingleBaseArticleSearchResultVo // 按更新时间倒序的文章
+	LastModified time.Time                            // 文章最近的更新或发布时间，没有文章时为零值
+	// 由站点地址、文章id与更新时间计算，文章被移入回收站或定时发布的文章上线时同样会变化
+	ETag string
+}
+
+// FeedArticles 订阅源中的文章，category为空时取全站，否则取该分类下的文章
+// origin为当前请求的协议与主机，未配置站点地址时以此生成链接
+func (fs *FeedService) FeedArticles(category, origin, selfUrl string) (*FeedSource, error) {
+	size := FeedSize()
+	params := vo.BaseParams{
+		UpdateTimeSort: bo.TimeSort{SortDirection: -1, SortFlag: true},
+		PageNumber:     1,
+		PageSize:       size,
+	}
+	var result *vo.BaseArticleSearchResultVo
+	var err error
+	if category == "" {
+		result, err = fs.ArticleDao.ArticleBaseSearch(vo.BaseArticleSearchVo{BaseParams: params}, "")
+	} else {
+		result, err = fs.ArticleDao.ArticleInCategory(vo.ArticleSearchByCategoryVo{
+			BaseParams:   params,
+			CategoryName: category,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	// 第一页最前面是置顶文章，订阅源只关心更新时间，重新排序之后截断
+	articles := result.Articles
+	sort.SliceStable(articles, func(i, j int) bool {
+		return articles[i].UpdateTime.After(articles[j].UpdateTime)
+	})
+	if int64(len(articles)) > size {
+		articles = articles[:size]
+	}
+	site := SiteUrl(origin)
+	source := &FeedSource{
+		Title:       SiteTitle(),
+		Site:        site,
+		Link:        site + "/",
+		Description: siteConfig().Description,
+		SelfUrl:     selfUrl,
+		Articles:    articles,
+	}
+	if category != "" {
+		source.Title = source.Title + " - " + category
+	}
+	hash := sha256.New()
+	hash.Write([]byte(source.Site + "\n" + source.Title + "\n"))
+	for _, val := range articles {
+		if val.UpdateTime.After(source.LastModified) {
+			source.LastModified = val.UpdateTime
+		}
+		if val.PublishTime.After(source.LastModified) {
+			source.LastModified = val.PublishTime
+		}
+		hash.Write([]byte(val.Id.Hex() + ":" + strconv.FormatInt(val.UpdateTime.UnixNano(), 10) + "\n"))
+	}
+	source.ETag = `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+	return source, nil
+}
+
+// RssFeed 生成RSS 2.0
+func (fs *FeedService) RssFeed(source *FeedSource) *vo.RssFeed {
+	feed := &vo.RssFeed{
+		Version:   "2.0",
+		XmlnsAtom: "http://www.w3.org/2005/Atom",
+		XmlnsDc:   "http://purl.org/dc/elements/1.1/",
+		XmlnsCont: "http://purl.org/rss/1.0/modules/content/",
+		Channel: vo.RssChannel{
+			Title:       source.Title,
+			Link:        source.Link,
+			Description: source.Description,
+			Generator:   "r0Website",
+			AtomLink:    vo.AtomLink{Href: source.SelfUrl, Rel: "self", Type: "application/rss+xml"},
+			Items:       make([]vo.RssItem, len(source.Articles)),
+		},
+	}
+	if !source.LastModified.IsZero() {
+		feed.Channel.LastBuildDate = source.LastModified.Format(time.RFC1123Z)
+	}
+	for index, val := range source.Articles {
+		link := ArticleLink(source.Site, val.Id.Hex())
+		feed.Channel.Items[index] = vo.RssItem{
+			Title:       val.Title,
+			Link:        link,
+			Guid:        vo.RssGuid{IsPermaLink: true, Value: link},
+			Description: val.Synopsis,
+			Content:     vo.CData{Text: renderArticle(&val).html},
+			Creator:     val.Author,
+			Categories:  val.Categories,
+			PubDate:     feedPublishTime(&val).Format(time.RFC1123Z),
+		}
+	}
+	return feed
+}
+
+// AtomFeed 生成Atom 1.0
+func (fs *FeedService) AtomFeed(source *FeedSource) *vo.AtomFeed {
+	updated := source.LastModified
+	if updated.IsZero() {
+		updated = time.Now()
+	}
+	feed := &vo.AtomFeed{
+		Title:    source.Title,
+		Subtitle: source.Description,
+		Id:       source.SelfUrl,
+		Updated:  updated.Format(time.RFC3339),
+		Links: []vo.AtomLink{
+			{Href: source.SelfUrl, Rel: "self", Type: "application/atom+xml"},
+			{Href: source.Link, Rel: "alternate", Type: "text/html"},
+		},
+		Entries: make([]vo.AtomEntry, len(source.Articles)),
+	}
+	for index, val := range source.Articles {
+		link := ArticleLink(source.Site, val.Id.Hex())
+		categories := make([]vo.AtomCategory, len(val.Categories))
+		for i, category := range val.Categories {
+			categories[i] = vo.AtomCategory{Term: category}
+		}
+		// Atom要求条目必须有作者
+		author := val.Author
+		if author == "" {
+			author = SiteTitle()
+		}
+		feed.Entries[index] = vo.AtomEntry{
+			Title:      val.Title,
+			Id:         link,
+			Link:       vo.AtomLink{Href: link, Rel: "alternate", Type: "text/html"},
+			Published:  feedPublishTime(&val).Format(time.RFC3339),
+			Updated:    val.UpdateTime.Format(time.RFC3339),
+			Author:     vo.AtomPerson{Name: author},
+			Categories: categories,
+			Summary:    val.Synopsis,
+			Content:    vo.AtomText{Type: "html", Value: renderArticle(&val).html},
+		}
+	}
+	return feed
+}
+
+// JsonFeed 生成JSON Feed 1.1
+func (fs *FeedService) JsonFeed(source *FeedSource) *vo.JsonFeed {
+	feed := &vo.JsonFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       source.Title,
+		HomePageUrl: source.Link,
+		FeedUrl:     source.SelfUrl,
+		Description: source.Description,
+		Items:       make([]vo.JsonFeedItem, len(source.Articles)),
+	}
+	for index, val := range source.Articles {
+		link := ArticleLink(source.Site, val.Id.Hex())
+		item := vo.JsonFeedItem{
+			Id:            link,
+			Url:           link,
+			Title:         val.Title,
+			ContentHtml:   renderArticle(&val).html,
+			Summary:       val.Synopsis,
+			Image:         val.PicUrl,
+			DatePublished: feedPublishTime(&val).Format(time.RFC3339),
+			DateModified:  val.UpdateTime.Format(time.RFC3339),
+			Tags:          val.Categories,
+		}
+		if val.Author != "" {
+			item.Authors = []vo.JsonFeedAuthor{{Name: val.Author}}
+		}
+		feed.Items[index] = item
+	}
+	return feed
+}
+
+// feedPublishTime 文章的发布时间，老文章没有发布时间时使用创建时间
+func feedPublishTime(article *vo.SingleBaseArticleSearchResultVo) time.Time {
+	if article.PublishTime.IsZero() {
+		return article.CreateTime
+	}
+	return article.PublishTime
+}
+
+// siteConfig 站点配置
+func siteConfig() config.Site {
+	if global.Config == nil {
+		return config.Site{}
+	}
+	return global.Config.Site
+}
+
+// SiteTitle 站点名称，未配置时使用作者名
+func SiteTitle() string {
+	if title := siteConfig().Title; title != "" {
+		return title
+	}
+	if global.Config != nil && global.Config.Author.Name != "" {
+		return global.Config.Author.Name
+	}
+	return "r0Website"
+}
+
+// SiteUrl 前台地址，不带结尾的"/"，未配置时使用origin，即当前请求的协议与主机
+func SiteUrl(origin string) string {
+	if site := strings.TrimSuffix(siteConfig().Url, "/"); site != "" {
+		return site
+	}
+	return strings.TrimSuffix(origin, "/")
+}
+
+// ArticleLink 文章在前台的地址，site为SiteUrl的结果
+func ArticleLink(site, id string) string {
+	return siteLink(site, siteConfig().ArticlePath, defaultArticlePath, "{id}", id)
+}
+
+// CategoryLink 文章分类在前台的地址
+func CategoryLink(site, name string) string {
+	return siteLink(site, siteConfig().CategoryPath, defaultCategoryPath, "{name}", url.PathEscape(name))
+}
+
+// AlbumLink 图集在前台的地址
+func AlbumLink(site, id string) string {
+	return siteLink(site, siteConfig().AlbumPath, defaultAlbumPath, "{id}", id)
+}
+
+// ImageCategoryLink 图片分类在前台的地址
+func ImageCategoryLink(site, id string) string {
+	return siteLink(site, siteConfig().ImageCategoryPath, defaultImageCategoryPath, "{id}", url.PathEscape(id))
+}
+
+// siteLink 按路径模板生成前台地址，未配置模板时使用默认模板
+func siteLink(site, path, defaultPath, placeholder, value string) string {
+	if path == "" {
+		path = defaultPath
+	}
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return site + strings.ReplaceAll(path, placeholder, value)
+}
+
+// FeedSize 订阅源中的文章数，默认20
+func FeedSize() int64 {
+	if global.Config != nil && global.Config.Site.FeedSize > 0 {
+		return global.Config.Site.FeedSize
+	}
+	return defaultFeedSize
+}
@@ -0,0 +1,304 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 全站搜索，一次查询文章、图集、图片与标签，归一化各自的评分之后统一排序
+ * @File:  search_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"r0Website-server/dao"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"sort"
+	"strings"
+)
+
+// 搜索结果的类型
+const (
+	SearchTypeArticle = "article"
+	SearchTypeAlbum   = "album"
+	SearchTypeImage   = "image"
+	SearchTypeTag     = "tag"
+)
+
+// searchTypes 所有类型，同分时按这个顺序排列
+var searchTypes = []string{SearchTypeArticle, SearchTypeAlbum, SearchTypeTag, SearchTypeImage}
+
+// searchTypeWeights 各类型的权重，归一化之后的评分乘以权重再统一排序
+var searchTypeWeights = map[string]float64{
+	SearchTypeArticle: 1,
+	SearchTypeAlbum:   0.8,
+	SearchTypeTag:     0.7,
+	SearchTypeImage:   0.6,
+}
+
+// searchCandidateLimit 每种类型最多参与排序的结果数
+const searchCandidateLimit = 50
+
+type SearchService struct {
+	ArticleService *ArticleService `R0Ioc:"true"`
+	AlbumDao       *dao.AlbumDao   `R0Ioc:"true"`
+	ImageDao       *dao.ImageDao   `R0Ioc:"true"`
+	TagDao         *dao.TagDao     `R0Ioc:"true"`
+}
+
+// Search 全站搜索，各类型的命中数总是全部返回，类型过滤只影响结果列表
+func (ss *SearchService) Search(params vo.BaseSearchVo) (*vo.SearchResultVo, error) {
+	keyword := strings.TrimSpace(params.Keyword)
+	if keyword == "" {
+		return nil, errors.New("Search: 关键字不能为空")
+	}
+	selected, err := searchSelectedTypes(params.Types)
+	if err != nil {
+		return nil, err
+	}
+	terms := utils.SearchTerms(keyword)
+	result := &vo.SearchResultVo{Keyword: keyword, Items: []vo.SearchItemVo{}, Facets: []vo.SearchFacetVo{}}
+	candidates := make([]vo.SearchItemVo, 0)
+	for _, searchType := range searchTypes {
+		var items []vo.SearchItemVo
+		var total int64
+		switch searchType {
+		case SearchTypeArticle:
+			items, total, err = ss.searchArticles(keyword)
+		case SearchTypeAlbum:
+			items, total, err = ss.searchAlbums(keyword)
+		case SearchTypeTag:
+			items, total, err = ss.searchTags(keyword)
+		case SearchTypeImage:
+			items, total, err = ss.searchImages(keyword)
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.Facets = append(result.Facets, vo.SearchFacetVo{Type: searchType, Count: total})
+		if selected[searchType] {
+			candidates = append(candidates, items...)
+		}
+	}
+	order := make(map[string]int, len(searchTypes))
+	for index, searchType := range searchTypes {
+		order[searchType] = index
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Score != candidates[j].Score {
+			return candidates[i].Score > candidates[j].Score
+		}
+		return order[candidates[i].Type] < order[candidates[j].Type]
+	})
+
+	pageNumber, pageSize := params.PageNumber, params.PageSize
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 10
+	}
+	if pageSize >= 200 {
+		pageSize = 200
+	}
+	result.PageNumber, result.PageSize = pageNumber, pageSize
+	result.TotalCount = int64(len(candidates))
+	start := (pageNumber - 1) * pageSize
+	if start < result.TotalCount {
+		end := start + pageSize
+		if end > result.TotalCount {
+			end = result.TotalCount
+		}
+		result.Items = candidates[start:end]
+	}
+	for index := range result.Items {
+		item := &result.Items[index]
+		item.HighlightTitle = utils.HighlightText(item.Title, terms)
+		// 文章的摘要代价较高，只为当前页生成
+		if item.Type == SearchTypeArticle {
+			item.Snippets = utils.HighlightSnippets(utils.MarkdownPlainText(item.Summary), terms, searchSnippetCount)
+			item.Summary = ""
+		}
+	}
+	result.AnsCount = int64(len(result.Items))
+	return result, nil
+}
+
+// searchSelectedTypes 解析类型过滤，为空时选中所有类型
+func searchSelectedTypes(types []string) (map[string]bool, error) {
+	selected := map[string]bool{}
+	for _, value := range types {
+		for _, searchType := range strings.Split(value, ",") {
+			searchType = strings.ToLower(strings.TrimSpace(searchType))
+			if searchType == "" {
+				continue
+			}
+			if _, ok := searchTypeWeights[searchType]; !ok {
+				return nil, errors.New("Search: 不支持的类型 " + searchType)
+			}
+			selected[searchType] = true
+		}
+	}
+	if len(selected) == 0 {
+		for _, searchType := range searchTypes {
+			selected[searchType] = true
+		}
+	}
+	return selected, nil
+}
+
+// searchArticles 按配置的搜索后端检索文章，Summary中暂存md内容，分页之后再生成摘要
+func (ss *SearchService) searchArticles(keyword string) ([]vo.SearchItemVo, int64, error) {
+	ans, err := ss.ArticleService.searchArticles(vo.BaseArticleSearchVo{
+		SearchText: keyword,
+		BaseParams: vo.BaseParams{PageNumber: 1, PageSize: searchCandidateLimit},
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	var maxScore float64
+	for _, val := range ans.Articles {
+		if val.Score > maxScore {
+			maxScore = val.Score
+		}
+	}
+	items := make([]vo.SearchItemVo, 0, len(ans.Articles))
+	for _, val := range ans.Articles {
+		createTime := val.CreateTime
+		items = append(items, vo.SearchItemVo{
+			Type:    SearchTypeArticle,
+			Id:      val.Id.Hex(),
+			Title:   val.Title,
+			Summary: val.Markdown,
+			Slug:    val.Slug,
+			Thumb:   val.PicUrl,
+			Tags:    val.Tags,
+			Time:    &createTime,
+			Score:   normalizedSearchScore(SearchTypeArticle, val.Score, maxScore),
+		})
+	}
+	return items, ans.TotalCount, nil
+}
+
+// searchAlbums 检索公开的图集
+func (ss *SearchService) searchAlbums(keyword string) ([]vo.SearchItemVo, int64, error) {
+	albums, total, err := ss.AlbumDao.SearchPublicAlbums(keyword, searchCandidateLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	var maxScore float64
+	for _, val := range albums {
+		if val.Score > maxScore {
+			maxScore = val.Score
+		}
+	}
+	items := make([]vo.SearchItemVo, 0, len(albums))
+	for _, val := range albums {
+		updatedAt := val.UpdatedAt.Local()
+		items = append(items, vo.SearchItemVo{
+			Type:    SearchTypeAlbum,
+			Id:      val.ID.Hex(),
+			Title:   val.Title,
+			Summary: val.Description,
+			Tags:    val.Tags,
+			Time:    &updatedAt,
+			Score:   normalizedSearchScore(SearchTypeAlbum, val.Score, maxScore),
+		})
+	}
+	return items, total, nil
+}
+
+// searchTags 检索文章的标签与图片的标签，文章的标签只统计对外可见的文章
+func (ss *SearchService) searchTags(keyword string) ([]vo.SearchItemVo, int64, error) {
+	items := make([]vo.SearchItemVo, 0)
+	articleTags, err := ss.ArticleService.ArticleDao.ArticleTagStats(true)
+	if err != nil {
+		return nil, 0, err
+	}
+	var total int64
+	for _, val := range articleTags {
+		if score := nameMatchScore(val.Tag, keyword); score > 0 {
+			total++
+			if len(items) < searchCandidateLimit {
+				items = append(items, vo.SearchItemVo{
+					Type:   SearchTypeTag,
+					Id:     val.Tag,
+					Title:  val.Tag,
+					Source: SearchTypeArticle,
+					Count:  val.Count,
+					Score:  searchTypeWeights[SearchTypeTag] * score,
+				})
+			}
+		}
+	}
+	imageTags, imageTotal, err := ss.TagDao.SearchTagsByKeyword(keyword, searchCandidateLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	for _, val := range imageTags {
+		title := val.DisplayName
+		if title == "" {
+			title = val.Name
+		}
+		score := nameMatchScore(val.Name, keyword)
+		if displayScore := nameMatchScore(val.DisplayName, keyword); displayScore > score {
+			score = displayScore
+		}
+		items = append(items, vo.SearchItemVo{
+			Type:   SearchTypeTag,
+			Id:     val.ID,
+			Title:  title,
+			Source: SearchTypeImage,
+			Count:  int64(val.ImageCount),
+			Score:  searchTypeWeights[SearchTypeTag] * score,
+		})
+	}
+	return items, total + imageTotal, nil
+}
+
+// searchImages 按图片名检索图片
+func (ss *SearchService) searchImages(keyword string) ([]vo.SearchItemVo, int64, error) {
+	images, total, err := ss.ImageDao.SearchImagesByNameLimit(keyword, searchCandidateLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+	items := make([]vo.SearchItemVo, 0, len(images))
+	for _, val := range images {
+		uploadedAt := val.UploadedAt.Local()
+		items = append(items, vo.SearchItemVo{
+			Type:  SearchTypeImage,
+			Id:    val.ID.Hex(),
+			Title: val.Name,
+			Thumb: val.ThumbURL,
+			Tags:  val.Tags,
+			Time:  &uploadedAt,
+			Score: searchTypeWeights[SearchTypeImage] * nameMatchScore(val.Name, keyword),
+		})
+	}
+	return items, total, nil
+}
+
+// normalizedSearchScore 将全文检索的评分按同类型的最高分归一化，再乘以类型的权重
+func normalizedSearchScore(searchType string, score, maxScore float64) float64 {
+	if maxScore <= 0 {
+		return 0
+	}
+	return searchTypeWeights[searchType] * score / maxScore
+}
+
+// nameMatchScore 名称与关键字的匹配程度：完全一致为1，前缀为0.8，包含为0.6，不匹配为0
+func nameMatchScore(name, keyword string) float64 {
+	name, keyword = strings.ToLower(name), strings.ToLower(keyword)
+	switch {
+	case name == "" || keyword == "":
+		return 0
+	case name == keyword:
+		return 1
+	case strings.HasPrefix(name, keyword):
+		return 0.8
+	case strings.Contains(name, keyword):
+		return 0.6
+	}
+	return 0
+}
@@ -0,0 +1,227 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 文章系列，按顺序串起拆分成多篇的长文
+ * @File:  series_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"r0Website-server/dao"
+	"r0Website-server/models/po"
+	"r0Website-server/models/vo"
+	"r0Website-server/utils"
+	"strings"
+	"time"
+)
+
+type SeriesService struct {
+	SeriesDao  *dao.SeriesDao  `R0Ioc:"true"`
+	ArticleDao *dao.ArticleDao `R0Ioc:"true"`
+}
+
+// CreateSeries 新建系列
+func (ss *SeriesService) CreateSeries(params vo.AdminSeriesAddVo) (*vo.AdminSeriesVo, error) {
+	title := strings.TrimSpace(params.Title)
+	if title == "" {
+		return nil, errors.New("CreateSeries: 系列标题不能为空")
+	}
+	now := time.Now()
+	series := &po.Series{
+		Title:       title,
+		Description: params.Description,
+		CreateTime:  now,
+		UpdateTime:  now,
+	}
+	articleIds, err := ss.checkSeriesArticles(params.ArticleIds, series)
+	if err != nil {
+		return nil, err
+	}
+	series.ArticleIds = articleIds
+	if err = ss.SeriesDao.CreateSeries(series); err != nil {
+		return nil, err
+	}
+	return ss.seriesToAdminVo(series)
+}
+
+// AllSeries 所有系列
+func (ss *SeriesService) AllSeries() (*vo.AdminSeriesListVo, error) {
+	list, err := ss.SeriesDao.AllSeries()
+	if err != nil {
+		return nil, err
+	}
+	result := &vo.AdminSeriesListVo{Series: make([]vo.AdminSeriesVo, len(list))}
+	for index := range list {
+		series, err := ss.seriesToAdminVo(&list[index])
+		if err != nil {
+			return nil, err
+		}
+		result.Series[index] = *series
+	}
+	result.TotalCount = int64(len(result.Series))
+	return result, nil
+}
+
+// SeriesDetail 某一系列
+func (ss *SeriesService) SeriesDetail(id string) (*vo.AdminSeriesVo, error) {
+	series, err := ss.SeriesDao.FindSeriesById(id)
+	if err != nil {
+		return nil, err
+	}
+	return ss.seriesToAdminVo(series)
+}
+
+// UpdateSeries 修改系列，只修改提交了的字段，文章列表整体替换
+func (ss *SeriesService) UpdateSeries(params vo.AdminSeriesUpdateVo, id string) (*vo.AdminSeriesVo, error) {
+	series, err := ss.SeriesDao.FindSeriesById(id)
+	if err != nil {
+		return nil, err
+	}
+	if params.Title != nil {
+		title := strings.TrimSpace(*params.Title)
+		if title == "" {
+			return nil, errors.New("UpdateSeries: 系列标题不能为空")
+		}
+		series.Title = title
+	}
+	if params.Description != nil {
+		series.Description = *params.Description
+	}
+	if params.ArticleIds != nil {
+		if series.ArticleIds, err = ss.checkSeriesArticles(*params.ArticleIds, series); err != nil {
+			return nil, err
+		}
+	}
+	series.UpdateTime = time.Now()
+	if _, err = ss.SeriesDao.UpdateSeries(series); err != nil {
+		return nil, err
+	}
+	return ss.seriesToAdminVo(series)
+}
+
+// DeleteSeries 删除系列，其中的文章不受影响
+func (ss *SeriesService) DeleteSeries(id string) (int64, error) {
+	series, err := ss.SeriesDao.FindSeriesById(id)
+	if err != nil {
+		return 0, err
+	}
+	return ss.SeriesDao.DeleteSeries(series.Id)
+}
+
+// checkSeriesArticles 去重并检查文章都存在，且没有被其他系列收录，文章id统一转为24位的hex
+func (ss *SeriesService) checkSeriesArticles(ids []string, series *po.Series) ([]string, error) {
+	articleIds := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if id = strings.TrimSpace(id); id != "" {
+			articleIds = append(articleIds, utils.String2HexString24(id))
+		}
+	}
+	articleIds = uniqueStringSlice(articleIds)
+	if len(articleIds) == 0 {
+		return articleIds, nil
+	}
+	articles, err := ss.ArticleDao.ArticlesBrief(articleIds)
+	if err != nil {
+		return nil, err
+	}
+	exists := make(map[string]bool, len(articles))
+	for _, val := range articles {
+		exists[val.Id.Hex()] = true
+	}
+	for _, id := range articleIds {
+		if !exists[id] {
+			return nil, errors.New("checkSeriesArticles: 文章不存在 " + id)
+		}
+	}
+	others, err := ss.SeriesDao.SeriesContainingArticles(articleIds, series.Id)
+	if err != nil {
+		return nil, err
+	}
+	if len(others) > 0 {
+		return nil, errors.New("checkSeriesArticles: 文章已经属于系列 " + others[0].Title)
+	}
+	return articleIds, nil
+}
+
+// seriesToAdminVo 转换为管理员视角下的系列，带上文章的标题与状态
+func (ss *SeriesService) seriesToAdminVo(series *po.Series) (*vo.AdminSeriesVo, error) {
+	articles, err := ss.ArticleDao.ArticlesBrief(series.ArticleIds)
+	if err != nil {
+		return nil, err
+	}
+	articleMap := make(map[string]*po.Article, len(articles))
+	for index := range articles {
+		articleMap[articles[index].Id.Hex()] = &articles[index]
+	}
+	result := &vo.AdminSeriesVo{
+		Id:          series.Id,
+		Title:       series.Title,
+		Description: series.Description,
+		Articles:    make([]vo.SeriesEntryVo, len(series.ArticleIds)),
+		CreateTime:  series.CreateTime.Local(),
+		UpdateTime:  series.UpdateTime.Local(),
+	}
+	for index, id := range series.ArticleIds {
+		entry := vo.SeriesEntryVo{Id: id, Missing: true}
+		if article, ok := articleMap[id]; ok {
+			entry = vo.SeriesEntryVo{
+				Id:         id,
+				Title:      article.Title,
+				DraftFlag:  article.DraftFlag,
+				DeleteFlag: article.DeleteFlag,
+			}
+		}
+		result.Articles[index] = entry
+	}
+	return result, nil
+}
+
+// articleSeriesContext 文章在系列中的位置，草稿、定时发布与回收站中的文章不计入
+// 文章不属于任何系列或自身不可见时返回nil
+func articleSeriesContext(
+	seriesDao *dao.SeriesDao, articleDao *dao.ArticleDao, articleId string,
+) (*vo.ArticleSeriesContextVo, error) {
+	series, err := seriesDao.FindSeriesByArticle(articleId)
+	if err != nil || series == nil {
+		return nil, err
+	}
+	articles, err := articleDao.ArticlesBrief(series.ArticleIds)
+	if err != nil {
+		return nil, err
+	}
+	articleMap := make(map[string]*po.Article, len(articles))
+	for index := range articles {
+		articleMap[articles[index].Id.Hex()] = &articles[index]
+	}
+	result := &vo.ArticleSeriesContextVo{
+		Id:          series.Id,
+		Title:       series.Title,
+		Description: series.Description,
+		Entries:     []vo.SeriesEntryVo{},
+	}
+	for _, id := range series.ArticleIds {
+		article, ok := articleMap[id]
+		if !ok || !articlePublicVisible(article) {
+			continue
+		}
+		result.Entries = append(result.Entries, vo.SeriesEntryVo{Id: id, Title: article.Title})
+		if id == articleId {
+			result.Index = len(result.Entries)
+		}
+	}
+	if result.Index == 0 {
+		return nil, nil
+	}
+	result.Total = len(result.Entries)
+	if result.Index > 1 {
+		result.Prev = &result.Entries[result.Index-2]
+	}
+	if result.Index < result.Total {
+		result.Next = &result.Entries[result.Index]
+	}
+	return result, nil
+}
@@ -0,0 +1,194 @@
+// Package service
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 站点地图与robots，覆盖已发布的文章、文章分类、公开图集与图片分类
+ * @File:  sitemap_service
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package service
+
+import (
+	"errors"
+	"fmt"
+	"r0Website-server/dao"
+	"r0Website-server/models/vo"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// SitemapMaxUrls 单个站点地图最多的地址数，超过之后改为站点地图索引
+	SitemapMaxUrls = 50000
+	// sitemapCacheTTL 站点地图的缓存时间，爬虫频繁访问时不必每次都扫全表
+	sitemapCacheTTL = 10 * time.Minute
+)
+
+// sitemapCache 站点地图的缓存，爬虫与站点地图索引的各页共用
+var sitemapCache struct {
+	sync.Mutex
+	site    string // 生成地址时使用的站点地址，变化时重新生成
+	urls    []vo.SitemapUrl
+	buildAt time.Time
+}
+
+type SitemapService struct {
+	ArticleDao       *dao.ArticleDao       `R0Ioc:"true"`
+	AlbumDao         *dao.AlbumDao         `R0Ioc:"true"`
+	ImageCategoryDao *dao.ImageCategoryDao `R0Ioc:"true"`
+}
+
+// Sitemap 地址不超过上限时返回站点地图，否则返回站点地图索引，索引中的地址以origin为前缀
+// 未配置站点地址时，站点地图中的地址同样以origin为前缀
+func (ss *SitemapService) Sitemap(origin string) (interface{}, error) {
+	urls, err := ss.sitemapUrls(SiteUrl(origin))
+	if err != nil {
+		return nil, err
+	}
+	if len(urls) <= SitemapMaxUrls {
+		return &vo.SitemapUrlSet{Urls: urls}, nil
+	}
+	index := &vo.SitemapIndex{}
+	for page := 1; (page-1)*SitemapMaxUrls < len(urls); page++ {
+		index.Sitemaps = append(index.Sitemaps, vo.SitemapRef{
+			Loc:     fmt.Sprintf("%s/sitemap/%d.xml", strings.TrimSuffix(origin, "/"), page),
+			LastMod: maxLastMod(sitemapPage(urls, page)),
+		})
+	}
+	return index, nil
+}
+
+// SitemapPage 站点地图索引中的第page个站点地图，页码从1开始
+func (ss *SitemapService) SitemapPage(origin string, page int) (*vo.SitemapUrlSet, error) {
+	urls, err := ss.sitemapUrls(SiteUrl(origin))
+	if err != nil {
+		return nil, err
+	}
+	pageUrls := sitemapPage(urls, page)
+	if len(pageUrls) == 0 {
+		return nil, errors.New("SitemapPage: 站点地图不存在")
+	}
+	return &vo.SitemapUrlSet{Urls: pageUrls}, nil
+}
+
+// Robots robots.txt的内容
+func (ss *SitemapService) Robots(origin string) string {
+	var builder strings.Builder
+	builder.WriteString("User-agent: *\n")
+	builder.WriteString("Disallow: /api/admin/\n")
+	builder.WriteString("\n")
+	builder.WriteString("Sitemap: " + strings.TrimSuffix(origin, "/") + "/sitemap.xml\n")
+	return builder.String()
+}
+
+// sitemapUrls 站点地图中的所有地址，以site为前缀，带缓存
+func (ss *SitemapService) sitemapUrls(site string) ([]vo.SitemapUrl, error) {
+	sitemapCache.Lock()
+	defer sitemapCache.Unlock()
+	if sitemapCache.urls != nil && sitemapCache.site == site && time.Since(sitemapCache.buildAt) < sitemapCacheTTL {
+		return sitemapCache.urls, nil
+	}
+	urls, err := ss.buildSitemapUrls(site)
+	if err != nil {
+		return nil, err
+	}
+	sitemapCache.site = site
+	sitemapCache.urls = urls
+	sitemapCache.buildAt = time.Now()
+	return urls, nil
+}
+
+// buildSitemapUrls 扫描文章、文章分类、公开图集与图片分类生成地址
+// 文章分类没有更新时间，取其下对外可见文章最新的更新时间，没有可见文章的分类不收录
+func (ss *SitemapService) buildSitemapUrls(site string) ([]vo.SitemapUrl, error) {
+	articles, err := ss.ArticleDao.PublishedArticlesBrief()
+	if err != nil {
+		return nil, err
+	}
+	albums, err := ss.AlbumDao.ListPublicAlbumsBrief()
+	if err != nil {
+		return nil, err
+	}
+	imageCategories, err := ss.ImageCategoryDao.ListCategoriesBrief()
+	if err != nil {
+		return nil, err
+	}
+	urls := make([]vo.SitemapUrl, 0, len(articles)+len(albums)+len(imageCategories)+1)
+	home := vo.SitemapUrl{Loc: site + "/", ChangeFreq: "daily", Priority: "1.0"}
+	if len(articles) > 0 {
+		home.LastMod = sitemapTime(articles[0].UpdateTime)
+	}
+	urls = append(urls, home)
+	categoryLastMod := map[string]time.Time{}
+	var categoryNames []string
+	for _, val := range articles {
+		urls = append(urls, vo.SitemapUrl{
+			Loc:      ArticleLink(site, val.Id.Hex()),
+			LastMod:  sitemapTime(val.UpdateTime),
+			Priority: "0.8",
+		})
+		for _, category := range val.Categories {
+			// 文章按更新时间倒序，第一次出现时就是该分类最新的更新时间
+			if _, ok := categoryLastMod[category]; !ok {
+				categoryLastMod[category] = val.UpdateTime
+				categoryNames = append(categoryNames, category)
+			}
+		}
+	}
+	for _, name := range categoryNames {
+		urls = append(urls, vo.SitemapUrl{
+			Loc:        CategoryLink(site, name),
+			LastMod:    sitemapTime(categoryLastMod[name]),
+			ChangeFreq: "weekly",
+			Priority:   "0.6",
+		})
+	}
+	for _, val := range albums {
+		urls = append(urls, vo.SitemapUrl{
+			Loc:      AlbumLink(site, val.ID.Hex()),
+			LastMod:  sitemapTime(val.UpdatedAt),
+			Priority: "0.5",
+		})
+	}
+	for _, val := range imageCategories {
+		urls = append(urls, vo.SitemapUrl{
+			Loc:      ImageCategoryLink(site, val.ID),
+			LastMod:  sitemapTime(val.UpdatedAt),
+			Priority: "0.5",
+		})
+	}
+	return urls, nil
+}
+
+// sitemapPage 第page页的地址，超出范围时为空
+func sitemapPage(urls []vo.SitemapUrl, page int) []vo.SitemapUrl {
+	if page <= 0 || (page-1)*SitemapMaxUrls >= len(urls) {
+		return nil
+	}
+	end := page * SitemapMaxUrls
+	if end > len(urls) {
+		end = len(urls)
+	}
+	return urls[(page-1)*SitemapMaxUrls : end]
+}
+
+// maxLastMod 一组地址中最新的lastmod，W3C时间格式可以直接按字符串比较
+func maxLastMod(urls []vo.SitemapUrl) string {
+	var ans string
+	for _, val := range urls {
+		if val.LastMod > ans {
+			ans = val.LastMod
+		}
+	}
+	return ans
+}
+
+// sitemapTime 站点地图使用的W3C时间格式，零值时省略
+func sitemapTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -2,6 +2,7 @@
 # shellcheck disable=SC1068
 pid=$(tail -1 ".pid")
 echo $pid
-kill -9 $pid
+# SIGTERM让服务完成处理中的请求并写入尚未写入的计数之后退出
+kill $pid
 
 # chmod +x ./stop-server.sh && ./stop-server.sh
\ No newline at end of file
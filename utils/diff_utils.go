@@ -0,0 +1,210 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 行级别的文本差异比较，输出unified diff
+ * 	FOLLOW: http://www.xmailserver.org/diff2.pdf (Myers, An O(ND) Difference Algorithm)
+ * @File:  diff_utils
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffMaxEdit 最大编辑距离，超过之后放弃求最短编辑序列，直接视为整段替换
+// 防止两篇完全不同的长文把内存吃光
+const diffMaxEdit = 2000
+
+const (
+	diffEqual  = ' '
+	diffDelete = '-'
+	diffInsert = '+'
+)
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff 生成from到to的unified diff，context为每个hunk前后保留的上下文行数
+// 两者内容一致时返回空串
+func UnifiedDiff(from, to, fromName, toName string, context int) string {
+	if from == to {
+		return ""
+	}
+	ops := diffLines(splitLines(from), splitLines(to))
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("--- %s\n+++ %s\n", fromName, toName))
+	// aPos/bPos 记录第i个操作之前两边各自消耗了多少行
+	aPos := make([]int, len(ops)+1)
+	bPos := make([]int, len(ops)+1)
+	for i, op := range ops {
+		aPos[i+1], bPos[i+1] = aPos[i], bPos[i]
+		if op.kind != diffInsert {
+			aPos[i+1]++
+		}
+		if op.kind != diffDelete {
+			bPos[i+1]++
+		}
+	}
+	for i := 0; i < len(ops); {
+		if ops[i].kind == diffEqual {
+			i++
+			continue
+		}
+		// 找到一个hunk的范围，相邻变化之间的相同行不超过2*context行时合并
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+		last := i
+		for j := i + 1; j < len(ops) && j-last-1 <= 2*context; j++ {
+			if ops[j].kind != diffEqual {
+				last = j
+			}
+		}
+		end := last + context + 1
+		if end > len(ops) {
+			end = len(ops)
+		}
+		aCount, bCount := aPos[end]-aPos[start], bPos[end]-bPos[start]
+		aStart, bStart := aPos[start], bPos[start]
+		if aCount > 0 {
+			aStart++
+		}
+		if bCount > 0 {
+			bStart++
+		}
+		builder.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", aStart, aCount, bStart, bCount))
+		for _, op := range ops[start:end] {
+			builder.WriteByte(op.kind)
+			builder.WriteString(op.line)
+			builder.WriteByte('\n')
+		}
+		i = end
+	}
+	return builder.String()
+}
+
+// splitLines 按行切分，忽略结尾的换行
+func splitLines(text string) []string {
+	if text == "" {
+		return []string{}
+	}
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines 求a到b的最短编辑序列，先剥离公共前后缀以减少计算量
+func diffLines(a, b []string) []diffOp {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix &&
+		a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{kind: diffEqual, line: line})
+	}
+	ops = append(ops, myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{kind: diffEqual, line: line})
+	}
+	return ops
+}
+
+// myersDiff Myers O(ND)差分，trace[d]保存第d轮结束时k∈[-d,d]上的最远x
+func myersDiff(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	max := n + m
+	if max == 0 {
+		return nil
+	}
+	v := make([]int, 2*max+2)
+	var trace [][]int
+	for d := 0; d <= max; d++ {
+		if d > diffMaxEdit {
+			return replaceDiff(a, b)
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[max+k-1] < v[max+k+1]) {
+				x = v[max+k+1]
+			} else {
+				x = v[max+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[max+k] = x
+			if x >= n && y >= m {
+				return backtrackDiff(a, b, trace, d)
+			}
+		}
+		snapshot := make([]int, 2*d+1)
+		copy(snapshot, v[max-d:max+d+1])
+		trace = append(trace, snapshot)
+	}
+	return replaceDiff(a, b)
+}
+
+// backtrackDiff 从终点沿trace回溯出编辑序列
+func backtrackDiff(a, b []string, trace [][]int, depth int) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	x, y := len(a), len(b)
+	for d := depth; d > 0; d-- {
+		prev := trace[d-1]
+		k := x - y
+		var prevK int
+		// prev 覆盖 k∈[-(d-1), d-1]，下标需要偏移 d-1
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := prev[prevK+d-1]
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{kind: diffEqual, line: a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, diffOp{kind: diffInsert, line: b[y-1]})
+		} else {
+			ops = append(ops, diffOp{kind: diffDelete, line: a[x-1]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, diffOp{kind: diffEqual, line: a[x-1]})
+		x--
+		y--
+	}
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
+
+// replaceDiff 整段替换
+func replaceDiff(a, b []string) []diffOp {
+	ops := make([]diffOp, 0, len(a)+len(b))
+	for _, line := range a {
+		ops = append(ops, diffOp{kind: diffDelete, line: line})
+	}
+	for _, line := range b {
+		ops = append(ops, diffOp{kind: diffInsert, line: line})
+	}
+	return ops
+}
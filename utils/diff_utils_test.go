@@ -0,0 +1,73 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	cases := []struct {
+		name     string
+		from, to string
+		context  int
+		want     string
+	}{
+		{
+			name: "same",
+			from: "a\nb\n", to: "a\nb\n", context: 3,
+			want: "",
+		},
+		{
+			name: "replace one line",
+			from: "a\nb\nc\nd\ne\n", to: "a\nb\nx\nd\ne\n", context: 1,
+			want: "--- old\n+++ new\n@@ -2,3 +2,3 @@\n b\n-c\n+x\n d\n",
+		},
+		{
+			name: "two hunks",
+			from: "1\n2\n3\n4\n5\n6\n7\n8\n9\n", to: "0\n2\n3\n4\n5\n6\n7\n8\n10\n", context: 1,
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n-1\n+0\n 2\n@@ -8,2 +8,2 @@\n 8\n-9\n+10\n",
+		},
+		{
+			name: "close changes merge into one hunk",
+			from: "1\n2\n3\n4\n", to: "0\n2\n3\n5\n", context: 1,
+			want: "--- old\n+++ new\n@@ -1,4 +1,4 @@\n-1\n+0\n 2\n 3\n-4\n+5\n",
+		},
+		{
+			name: "changes further apart stay separate",
+			from: "1\n2\n3\n4\n5\n", to: "0\n2\n3\n4\n6\n", context: 1,
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n-1\n+0\n 2\n@@ -4,2 +4,2 @@\n 4\n-5\n+6\n",
+		},
+		{
+			name: "from empty",
+			from: "", to: "a\nb\n", context: 3,
+			want: "--- old\n+++ new\n@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			name: "to empty",
+			from: "a\nb\n", to: "", context: 3,
+			want: "--- old\n+++ new\n@@ -1,2 +0,0 @@\n-a\n-b\n",
+		},
+		{
+			name: "crlf is ignored",
+			from: "a\r\nb\r\n", to: "a\nc\n", context: 3,
+			want: "--- old\n+++ new\n@@ -1,2 +1,2 @@\n a\n-b\n+c\n",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := UnifiedDiff(c.from, c.to, "old", "new", c.context); got != c.want {
+				t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, c.want)
+			}
+		})
+	}
+}
+
+func TestUnifiedDiffMinimal(t *testing.T) {
+	// 中间插入一行时其余行都应当保持不变
+	from := strings.Repeat("line\n", 5) + "end\n"
+	to := strings.Repeat("line\n", 3) + "new\n" + strings.Repeat("line\n", 2) + "end\n"
+	want := "--- old\n+++ new\n@@ -3,0 +4,1 @@\n+new\n"
+	if got := UnifiedDiff(from, to, "old", "new", 0); got != want {
+		t.Errorf("UnifiedDiff() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,272 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 解析Hexo/Hugo/Jekyll文章开头的front matter，支持---包裹的YAML与+++包裹的TOML
+ * 	导出时统一写为YAML
+ * @File:  front_matter
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"r0Website-server/models/bo"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// frontMatterTimeLayouts front matter中常见的时间格式，没有时区的按本地时间处理
+var frontMatterTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02 15:04:05",
+	"2006/01/02 15:04",
+	"2006/01/02",
+}
+
+// ParseFrontMatter 拆分文章开头的front matter与正文
+// 没有front matter时返回nil与原文；front matter没有闭合时视为正文的一部分
+func ParseFrontMatter(content string) (*bo.FrontMatter, string, error) {
+	text := strings.TrimPrefix(content, "\ufeff")
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) == 0 {
+		return nil, content, nil
+	}
+	delimiter := strings.TrimSpace(lines[0])
+	if delimiter != "---" && delimiter != "+++" {
+		return nil, content, nil
+	}
+	end := -1
+	for index := 1; index < len(lines); index++ {
+		line := strings.TrimSpace(lines[index])
+		// Jekyll允许用...结束YAML
+		if line == delimiter || (delimiter == "---" && line == "...") {
+			end = index
+			break
+		}
+	}
+	if end < 0 {
+		return nil, content, nil
+	}
+	raw := strings.Join(lines[1:end], "")
+	body := strings.TrimLeft(strings.Join(lines[end+1:], ""), "\r\n")
+	values := map[string]interface{}{}
+	if delimiter == "---" {
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(raw), &root); err != nil {
+			return nil, content, errors.New("ParseFrontMatter: YAML格式错误 " + err.Error())
+		}
+		if len(root.Content) > 0 {
+			mapping, ok := yamlNodeValue(root.Content[0]).(map[string]interface{})
+			if !ok {
+				return nil, content, errors.New("ParseFrontMatter: front matter不是键值对")
+			}
+			values = mapping
+		}
+	} else if err := toml.Unmarshal([]byte(raw), &values); err != nil {
+		return nil, content, errors.New("ParseFrontMatter: TOML格式错误 " + err.Error())
+	}
+	matter, err := frontMatterFromValues(values)
+	if err != nil {
+		return nil, content, err
+	}
+	return matter, body, nil
+}
+
+// RenderFrontMatter 将matter序列化为YAML的front matter并拼接在正文之前
+func RenderFrontMatter(matter interface{}, body string) (string, error) {
+	content, err := yaml.Marshal(matter)
+	if err != nil {
+		return "", err
+	}
+	return "---\n" + string(content) + "---\n\n" + body, nil
+}
+
+// yamlNodeValue 将YAML节点转为通用的值，标量一律保留原始的字符串，避免时间被按UTC解析
+func yamlNodeValue(node *yaml.Node) interface{} {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			return yamlNodeValue(node.Content[0])
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			return yamlNodeValue(node.Alias)
+		}
+	case yaml.SequenceNode:
+		list := make([]interface{}, 0, len(node.Content))
+		for _, child := range node.Content {
+			list = append(list, yamlNodeValue(child))
+		}
+		return list
+	case yaml.MappingNode:
+		mapping := make(map[string]interface{}, len(node.Content)/2)
+		for index := 0; index+1 < len(node.Content); index += 2 {
+			mapping[node.Content[index].Value] = yamlNodeValue(node.Content[index+1])
+		}
+		return mapping
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil
+		}
+		return node.Value
+	}
+	return nil
+}
+
+// frontMatterFromValues 按各个静态博客常用的键名提取元信息，键名不区分大小写
+func frontMatterFromValues(values map[string]interface{}) (*bo.FrontMatter, error) {
+	matter := &bo.FrontMatter{}
+	var err error
+	for key, value := range values {
+		if value == nil {
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "title":
+			matter.Title = frontMatterString(value)
+		case "author":
+			matter.Author = frontMatterString(value)
+		case "description", "excerpt", "summary":
+			if matter.Synopsis == "" {
+				matter.Synopsis = frontMatterString(value)
+			}
+		case "slug":
+			matter.Slug = frontMatterString(value)
+		case "cover", "image", "thumbnail", "banner":
+			if matter.PicUrl == "" {
+				matter.PicUrl = frontMatterString(value)
+			}
+		case "tags":
+			matter.Tags = appendUnique(matter.Tags, frontMatterList(value)...)
+		case "categories", "category":
+			matter.Categories = appendUnique(matter.Categories, frontMatterList(value)...)
+		case "draft":
+			matter.Draft = matter.Draft || frontMatterBool(value)
+		case "published":
+			matter.Draft = matter.Draft || !frontMatterBool(value)
+		case "deleted":
+			matter.Deleted = frontMatterBool(value)
+		case "date":
+			matter.Date, err = frontMatterTime(key, value)
+		case "updated", "lastmod":
+			matter.Updated, err = frontMatterTime(key, value)
+		case "publishdate", "publish_date":
+			matter.PublishDate, err = frontMatterTime(key, value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return matter, nil
+}
+
+// frontMatterString 标量转为字符串
+func frontMatterString(value interface{}) string {
+	switch val := value.(type) {
+	case string:
+		return strings.TrimSpace(val)
+	case time.Time:
+		return val.Format(time.RFC3339)
+	case []interface{}:
+		return strings.Join(frontMatterList(val), ",")
+	}
+	return strings.TrimSpace(fmt.Sprint(value))
+}
+
+// frontMatterList 列表或分隔的字符串转为去掉空值的列表，嵌套的列表会被展开
+// 字符串中有逗号时按逗号分隔，否则按空白分隔，与Jekyll的处理一致
+func frontMatterList(value interface{}) []string {
+	list := make([]string, 0)
+	switch val := value.(type) {
+	case []interface{}:
+		// 列表中的字符串是完整的值，不再分隔
+		for _, item := range val {
+			if text, ok := item.(string); ok {
+				if text = strings.TrimSpace(text); text != "" {
+					list = append(list, text)
+				}
+				continue
+			}
+			list = append(list, frontMatterList(item)...)
+		}
+	case string:
+		var parts []string
+		if strings.Contains(val, ",") {
+			parts = strings.Split(val, ",")
+		} else {
+			parts = strings.Fields(val)
+		}
+		for _, part := range parts {
+			if part = strings.TrimSpace(part); part != "" {
+				list = append(list, part)
+			}
+		}
+	case nil:
+	default:
+		if item := frontMatterString(val); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// frontMatterBool 布尔值，无法识别时为false
+func frontMatterBool(value interface{}) bool {
+	switch val := value.(type) {
+	case bool:
+		return val
+	case string:
+		val = strings.ToLower(strings.TrimSpace(val))
+		flag, _ := strconv.ParseBool(val)
+		return flag || val == "yes"
+	}
+	return false
+}
+
+// frontMatterTime 时间，TOML中带时区的时间直接使用，其余按frontMatterTimeLayouts解析
+func frontMatterTime(key string, value interface{}) (time.Time, error) {
+	if val, ok := value.(time.Time); ok {
+		return val, nil
+	}
+	text := frontMatterString(value)
+	if text == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range frontMatterTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, text, time.Local); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, errors.New("ParseFrontMatter: 无法识别" + key + "的时间格式 " + text)
+}
+
+// appendUnique 追加尚未出现过的值
+func appendUnique(list []string, values ...string) []string {
+	for _, value := range values {
+		exists := false
+		for _, item := range list {
+			if item == value {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			list = append(list, value)
+		}
+	}
+	return list
+}
@@ -0,0 +1,362 @@
+// Package fulltext
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 进程内的倒排索引，BM25F评分，支持字段权重与前缀查询，可以持久化到磁盘
+ * 	分词由调用方提供，索引本身不关心语言
+ * @File:  fulltext
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package fulltext
+
+import (
+	"encoding/gob"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// indexFormatVersion 持久化格式的版本，格式变化之后旧的文件会被拒绝加载
+const indexFormatVersion = 1
+
+// maxPrefixExpansion 一个前缀最多展开的词数，避免过短的前缀拖慢查询
+const maxPrefixExpansion = 64
+
+// Tokenizer 将文本切分为词，同一个词出现多次时需要重复返回
+type Tokenizer func(text string) []string
+
+// Field 被索引的字段与其权重
+type Field struct {
+	Name  string
+	Boost float64
+}
+
+// Hit 一条命中的文档
+type Hit struct {
+	Id    string
+	Score float64
+}
+
+// document 文档在各字段中的词频与长度，倒排可以完全由它重建
+type document struct {
+	Version int64              // 由调用方定义的版本，用于判断文档是否需要重新索引
+	Lengths []int              // 各字段的词数
+	Freqs   map[string][]int32 // 词在各字段中出现的次数
+}
+
+// snapshot 持久化到磁盘的内容
+type snapshot struct {
+	FormatVersion int
+	Fields        []string
+	Docs          map[string]*document
+}
+
+// Index 倒排索引，并发安全
+type Index struct {
+	mu          sync.RWMutex
+	tokenizer   Tokenizer
+	fields      []Field
+	k1, b       float64
+	docs        map[string]*document
+	postings    map[string]map[string][]int32
+	fieldTotals []int64
+	terms       []string // 有序的词表，用于前缀查询，为nil时需要重建
+	generation  uint64   // 每次变动加一
+	saved       uint64   // 最近一次保存时的generation
+}
+
+// NewIndex 创建一个空的索引，字段的顺序即Add时值的顺序
+func NewIndex(tokenizer Tokenizer, fields ...Field) *Index {
+	return &Index{
+		tokenizer:   tokenizer,
+		fields:      fields,
+		k1:          1.2,
+		b:           0.75,
+		docs:        map[string]*document{},
+		postings:    map[string]map[string][]int32{},
+		fieldTotals: make([]int64, len(fields)),
+	}
+}
+
+// Add 索引一篇文档，已存在时替换，values与字段一一对应
+func (ix *Index) Add(id string, version int64, values ...string) {
+	doc := &document{Version: version, Lengths: make([]int, len(ix.fields)), Freqs: map[string][]int32{}}
+	for field := range ix.fields {
+		if field >= len(values) {
+			break
+		}
+		tokens := ix.tokenizer(values[field])
+		doc.Lengths[field] = len(tokens)
+		for _, token := range tokens {
+			freqs, ok := doc.Freqs[token]
+			if !ok {
+				freqs = make([]int32, len(ix.fields))
+				doc.Freqs[token] = freqs
+			}
+			freqs[field]++
+		}
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.remove(id)
+	ix.insert(id, doc)
+	ix.generation++
+}
+
+// Remove 移除一篇文档，文档不存在时返回false
+func (ix *Index) Remove(id string) bool {
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	if !ix.remove(id) {
+		return false
+	}
+	ix.generation++
+	return true
+}
+
+// Versions 所有文档的版本
+func (ix *Index) Versions() map[string]int64 {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	versions := make(map[string]int64, len(ix.docs))
+	for id, doc := range ix.docs {
+		versions[id] = doc.Version
+	}
+	return versions
+}
+
+// Len 文档数
+func (ix *Index) Len() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.docs)
+}
+
+// Terms 词表的大小
+func (ix *Index) Terms() int {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return len(ix.postings)
+}
+
+// Dirty 上一次保存之后是否有变动
+func (ix *Index) Dirty() bool {
+	ix.mu.RLock()
+	defer ix.mu.RUnlock()
+	return ix.generation != ix.saved
+}
+
+// Search 查询所有命中的文档，按分数倒序
+// 查询中以*结尾的部分作为前缀匹配，其余部分经过分词之后按词匹配，各个词之间是或的关系
+func (ix *Index) Search(query string) []Hit {
+	ix.mu.RLock()
+	for ix.terms == nil {
+		// 词表在文档变动之后才需要重建，升级为写锁重建之后再回到读锁
+		ix.mu.RUnlock()
+		ix.mu.Lock()
+		if ix.terms == nil {
+			ix.rebuildTerms()
+		}
+		ix.mu.Unlock()
+		ix.mu.RLock()
+	}
+	defer ix.mu.RUnlock()
+	terms := ix.queryTerms(query)
+	total := float64(len(ix.docs))
+	if len(terms) == 0 || total == 0 {
+		return []Hit{}
+	}
+	averages := make([]float64, len(ix.fields))
+	for field, length := range ix.fieldTotals {
+		averages[field] = float64(length) / total
+	}
+	scores := map[string]float64{}
+	for _, term := range terms {
+		postings := ix.postings[term]
+		df := float64(len(postings))
+		idf := math.Log(1 + (total-df+0.5)/(df+0.5))
+		for id, freqs := range postings {
+			lengths := ix.docs[id].Lengths
+			var tf float64
+			for field, freq := range freqs {
+				if freq == 0 {
+					continue
+				}
+				norm := 1 - ix.b
+				if averages[field] > 0 {
+					norm += ix.b * float64(lengths[field]) / averages[field]
+				}
+				tf += ix.fields[field].Boost * float64(freq) / norm
+			}
+			scores[id] += idf * tf * (ix.k1 + 1) / (tf + ix.k1)
+		}
+	}
+	hits := make([]Hit, 0, len(scores))
+	for id, score := range scores {
+		hits = append(hits, Hit{Id: id, Score: score})
+	}
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Id < hits[j].Id
+	})
+	return hits
+}
+
+// Save 保存到磁盘，先写临时文件再替换
+func (ix *Index) Save(path string) error {
+	ix.mu.RLock()
+	data := snapshot{FormatVersion: indexFormatVersion, Fields: ix.fieldNames(), Docs: ix.docs}
+	generation := ix.generation
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		ix.mu.RUnlock()
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		ix.mu.RUnlock()
+		return err
+	}
+	err = gob.NewEncoder(tmp).Encode(&data)
+	ix.mu.RUnlock()
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	ix.mu.Lock()
+	// 保存期间的变动仍然需要下一次保存
+	if ix.saved < generation {
+		ix.saved = generation
+	}
+	ix.mu.Unlock()
+	return nil
+}
+
+// Load 从磁盘加载，替换当前的内容，字段或格式不一致时返回错误
+func (ix *Index) Load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	var data snapshot
+	if err = gob.NewDecoder(file).Decode(&data); err != nil {
+		return err
+	}
+	if data.FormatVersion != indexFormatVersion {
+		return errors.New("fulltext: 索引文件的格式版本不一致")
+	}
+	if strings.Join(data.Fields, ",") != strings.Join(ix.fieldNames(), ",") {
+		return errors.New("fulltext: 索引文件的字段不一致")
+	}
+	ix.mu.Lock()
+	defer ix.mu.Unlock()
+	ix.docs = map[string]*document{}
+	ix.postings = map[string]map[string][]int32{}
+	ix.fieldTotals = make([]int64, len(ix.fields))
+	for id, doc := range data.Docs {
+		ix.insert(id, doc)
+	}
+	ix.saved = ix.generation
+	return nil
+}
+
+// insert 将文档写入倒排，调用方需要持有写锁
+func (ix *Index) insert(id string, doc *document) {
+	ix.docs[id] = doc
+	for field, length := range doc.Lengths {
+		ix.fieldTotals[field] += int64(length)
+	}
+	for term, freqs := range doc.Freqs {
+		postings, ok := ix.postings[term]
+		if !ok {
+			postings = map[string][]int32{}
+			ix.postings[term] = postings
+			ix.terms = nil
+		}
+		postings[id] = freqs
+	}
+}
+
+// remove 将文档移出倒排，调用方需要持有写锁
+func (ix *Index) remove(id string) bool {
+	doc, ok := ix.docs[id]
+	if !ok {
+		return false
+	}
+	for field, length := range doc.Lengths {
+		ix.fieldTotals[field] -= int64(length)
+	}
+	for term := range doc.Freqs {
+		delete(ix.postings[term], id)
+		if len(ix.postings[term]) == 0 {
+			delete(ix.postings, term)
+			ix.terms = nil
+		}
+	}
+	delete(ix.docs, id)
+	return true
+}
+
+// rebuildTerms 重建有序的词表，调用方需要持有写锁
+func (ix *Index) rebuildTerms() {
+	ix.terms = make([]string, 0, len(ix.postings))
+	for term := range ix.postings {
+		ix.terms = append(ix.terms, term)
+	}
+	sort.Strings(ix.terms)
+}
+
+// queryTerms 查询对应的去重之后的词，前缀展开为词表中所有以它开头的词
+func (ix *Index) queryTerms(query string) []string {
+	terms := make([]string, 0)
+	seen := map[string]bool{}
+	add := func(term string) {
+		if _, ok := ix.postings[term]; ok && !seen[term] {
+			seen[term] = true
+			terms = append(terms, term)
+		}
+	}
+	plain := make([]string, 0)
+	for _, part := range strings.Fields(query) {
+		if !strings.HasSuffix(part, "*") {
+			plain = append(plain, part)
+			continue
+		}
+		prefix := strings.ToLower(strings.TrimRight(part, "*"))
+		if prefix == "" {
+			continue
+		}
+		start := sort.SearchStrings(ix.terms, prefix)
+		for index := start; index < len(ix.terms) && index-start < maxPrefixExpansion; index++ {
+			if !strings.HasPrefix(ix.terms[index], prefix) {
+				break
+			}
+			add(ix.terms[index])
+		}
+	}
+	for _, term := range ix.tokenizer(strings.Join(plain, " ")) {
+		add(term)
+	}
+	return terms
+}
+
+func (ix *Index) fieldNames() []string {
+	names := make([]string, len(ix.fields))
+	for index, field := range ix.fields {
+		names[index] = field.Name
+	}
+	return names
+}
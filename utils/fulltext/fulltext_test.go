@@ -0,0 +1,140 @@
+package fulltext
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func newTestIndex() *Index {
+	tokenizer := func(text string) []string { return strings.Fields(strings.ToLower(text)) }
+	return NewIndex(tokenizer, Field{Name: "title", Boost: 3}, Field{Name: "body", Boost: 1})
+}
+
+func hitIds(hits []Hit) []string {
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.Id)
+	}
+	return ids
+}
+
+func TestIndexRanking(t *testing.T) {
+	cases := []struct {
+		name  string
+		docs  [][3]string
+		query string
+		want  []string
+	}{
+		{
+			name:  "title outweighs body",
+			docs:  [][3]string{{"body", "rust notes", "go tips"}, {"title", "go tips", "rust notes"}},
+			query: "go",
+			want:  []string{"title", "body"},
+		},
+		{
+			name:  "more occurrences rank higher",
+			docs:  [][3]string{{"once", "", "go a b c"}, {"twice", "", "go go b c"}},
+			query: "go",
+			want:  []string{"twice", "once"},
+		},
+		{
+			name:  "more matched terms rank higher",
+			docs:  [][3]string{{"one", "", "go a"}, {"both", "", "go rust"}, {"none", "", "c d"}},
+			query: "go rust",
+			want:  []string{"both", "one"},
+		},
+		{
+			name:  "equal scores order by id",
+			docs:  [][3]string{{"b", "", "go"}, {"a", "", "go"}},
+			query: "go",
+			want:  []string{"a", "b"},
+		},
+		{
+			name:  "prefix query",
+			docs:  [][3]string{{"golang", "", "golang"}, {"gopher", "", "gopher"}, {"rust", "", "rust"}},
+			query: "gol*",
+			want:  []string{"golang"},
+		},
+		{
+			name:  "no match",
+			docs:  [][3]string{{"a", "", "go"}},
+			query: "rust",
+			want:  []string{},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			index := newTestIndex()
+			for _, doc := range c.docs {
+				index.Add(doc[0], 1, doc[1], doc[2])
+			}
+			if got := hitIds(index.Search(c.query)); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("Search(%q) = %v, want %v", c.query, got, c.want)
+			}
+		})
+	}
+}
+
+func TestIndexAddRemove(t *testing.T) {
+	index := newTestIndex()
+	index.Add("a", 1, "go", "tips")
+	index.Add("b", 1, "rust", "notes")
+	if got := hitIds(index.Search("go")); !reflect.DeepEqual(got, []string{"a"}) {
+		t.Fatalf("Search(go) = %v, want [a]", got)
+	}
+	// 再次添加时替换旧的内容
+	index.Add("a", 2, "rust", "tips")
+	if got := hitIds(index.Search("go")); len(got) != 0 {
+		t.Errorf("Search(go) after replace = %v, want none", got)
+	}
+	if got := hitIds(index.Search("rust")); !reflect.DeepEqual(got, []string{"a", "b"}) {
+		t.Errorf("Search(rust) after replace = %v, want [a b]", got)
+	}
+	if got := index.Versions(); !reflect.DeepEqual(got, map[string]int64{"a": 2, "b": 1}) {
+		t.Errorf("Versions() = %v", got)
+	}
+	if !index.Remove("a") {
+		t.Errorf("Remove(a) = false, want true")
+	}
+	if index.Remove("a") {
+		t.Errorf("second Remove(a) = true, want false")
+	}
+	if got := hitIds(index.Search("rust tips")); !reflect.DeepEqual(got, []string{"b"}) {
+		t.Errorf("Search after remove = %v, want [b]", got)
+	}
+	if index.Len() != 1 || index.Terms() != 2 {
+		t.Errorf("Len() = %d, Terms() = %d, want 1 and 2", index.Len(), index.Terms())
+	}
+}
+
+func TestIndexSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index")
+	index := newTestIndex()
+	index.Add("a", 1, "go", "tips")
+	index.Add("b", 2, "rust", "go notes")
+	if !index.Dirty() {
+		t.Fatalf("Dirty() = false after Add")
+	}
+	if err := index.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	if index.Dirty() {
+		t.Errorf("Dirty() = true after Save")
+	}
+	loaded := newTestIndex()
+	if err := loaded.Load(path); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.Search("go"), index.Search("go")) {
+		t.Errorf("loaded Search(go) = %v, want %v", loaded.Search("go"), index.Search("go"))
+	}
+	if !reflect.DeepEqual(loaded.Versions(), index.Versions()) {
+		t.Errorf("loaded Versions() = %v, want %v", loaded.Versions(), index.Versions())
+	}
+	other := NewIndex(strings.Fields, Field{Name: "title", Boost: 1})
+	if err := other.Load(path); err == nil {
+		t.Errorf("Load with different fields succeeded, want error")
+	}
+}
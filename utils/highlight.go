@@ -0,0 +1,134 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 代码块的语法高亮，输出基于class的html，配色由css决定
+ * 	FOLLOW: https://github.com/alecthomas/chroma
+ * @File:  highlight
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"github.com/alecthomas/chroma"
+	"github.com/alecthomas/chroma/formatters/html"
+	"github.com/alecthomas/chroma/lexers"
+	"github.com/alecthomas/chroma/styles"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultHighlightStyle 默认的配色
+const DefaultHighlightStyle = "github"
+
+// HighlightStyles 提供css的配色
+var HighlightStyles = []string{"github", "monokai", "dracula", "nord", "solarized-light", "solarized-dark"}
+
+// highlightLinesPattern 代码块信息中的高亮行，如 ```go {1,3-5}
+var highlightLinesPattern = regexp.MustCompile(`\{([\d,\-\s]*)\}`)
+
+// highlightCSSCache 各配色的css
+var highlightCSSCache sync.Map
+
+// codeBlockInfo 代码块信息 ```go {1,3-5} nolinenos
+type codeBlockInfo struct {
+	lang        string
+	highlighted [][2]int
+	lineNumbers bool
+}
+
+// parseCodeBlockInfo 解析代码块的信息串，语言后可以跟 {1,3-5} 指定高亮行，nolinenos 关闭行号
+func parseCodeBlockInfo(info string) codeBlockInfo {
+	ans := codeBlockInfo{lineNumbers: true}
+	if match := highlightLinesPattern.FindStringSubmatch(info); match != nil {
+		ans.highlighted = parseLineRanges(match[1])
+		info = strings.Replace(info, match[0], " ", 1)
+	}
+	for index, field := range strings.Fields(info) {
+		if field == "nolinenos" {
+			ans.lineNumbers = false
+		} else if index == 0 {
+			ans.lang = strings.ToLower(field)
+		}
+	}
+	return ans
+}
+
+// parseLineRanges 解析 1,3-5 这样的行号范围，非法的部分直接忽略
+func parseLineRanges(spec string) [][2]int {
+	var ranges [][2]int
+	for _, part := range strings.Split(spec, ",") {
+		bounds := strings.SplitN(strings.TrimSpace(part), "-", 2)
+		start, err := strconv.Atoi(strings.TrimSpace(bounds[0]))
+		if err != nil || start <= 0 {
+			continue
+		}
+		end := start
+		if len(bounds) == 2 {
+			if end, err = strconv.Atoi(strings.TrimSpace(bounds[1])); err != nil || end < start {
+				continue
+			}
+		}
+		ranges = append(ranges, [2]int{start, end})
+	}
+	return ranges
+}
+
+// highlightCode 高亮一个代码块，语言未知时返回false，由调用方按普通代码块输出
+// 没有指定语言时按纯文本处理，仍然带上行号
+func highlightCode(code, info string) (string, bool) {
+	block := parseCodeBlockInfo(info)
+	var lexer chroma.Lexer
+	if block.lang == "" {
+		lexer = lexers.Fallback
+	} else if lexer = lexers.Get(block.lang); lexer == nil {
+		return "", false
+	}
+	iterator, err := chroma.Coalesce(lexer).Tokenise(nil, code)
+	if err != nil {
+		return "", false
+	}
+	formatter := html.New(
+		html.WithClasses(true),
+		html.TabWidth(4),
+		html.WithLineNumbers(block.lineNumbers),
+		html.LineNumbersInTable(true),
+		html.HighlightLines(block.highlighted),
+	)
+	var buffer bytes.Buffer
+	if err = formatter.Format(&buffer, styles.Fallback, iterator); err != nil {
+		return "", false
+	}
+	return buffer.String(), true
+}
+
+// HighlightCSS 配色对应的css，只提供HighlightStyles中的配色
+func HighlightCSS(style string) (string, error) {
+	if style == "" {
+		style = DefaultHighlightStyle
+	}
+	if css, ok := highlightCSSCache.Load(style); ok {
+		return css.(string), nil
+	}
+	supported := false
+	for _, val := range HighlightStyles {
+		if val == style {
+			supported = true
+			break
+		}
+	}
+	if !supported {
+		return "", errors.New("HighlightCSS: 不支持的配色 " + style + "，可选 " + strings.Join(HighlightStyles, ","))
+	}
+	var buffer bytes.Buffer
+	if err := html.New(html.WithClasses(true)).WriteCSS(&buffer, styles.Get(style)); err != nil {
+		return "", err
+	}
+	highlightCSSCache.Store(style, buffer.String())
+	return buffer.String(), nil
+}
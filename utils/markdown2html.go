@@ -11,19 +11,134 @@
 package utils
 
 import (
+	"bytes"
 	"github.com/microcosm-cc/bluemonday"
 	"github.com/russross/blackfriday/v2"
+	"io"
+	"regexp"
+	"strconv"
 )
 
+// TocNode 文章目录中的一个标题，子标题挂在Children下
+type TocNode struct {
+	Level    int       `json:"level"`    // 标题级别 1-6
+	Id       string    `json:"id"`       // 锚点id，与html中标题的id一致
+	Text     string    `json:"text"`     // 标题的纯文本
+	Children []TocNode `json:"children"` // 子标题
+}
+
+const markdownExtensions = blackfriday.CommonExtensions |
+	blackfriday.HardLineBreak |
+	blackfriday.AutoHeadingIDs |
+	blackfriday.Autolink
+
+// headingIdPattern 标题锚点允许的字符，UGCPolicy默认只允许ascii，中文标题的锚点会被过滤掉
+var headingIdPattern = regexp.MustCompile(`^[\p{L}\p{N}\-_:.]+$`)
+
+// highlightClassPattern 语法高亮输出的class，如 chroma、lntable、kd
+var highlightClassPattern = regexp.MustCompile(`^[a-zA-Z0-9\-_ ]{1,64}$`)
+
+// markdownPolicy 在UGCPolicy的基础上允许标题带上非ascii的锚点，并保留语法高亮的class
+var markdownPolicy = func() *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+	policy.AllowAttrs("id").Matching(headingIdPattern).OnElements("h1", "h2", "h3", "h4", "h5", "h6")
+	policy.AllowAttrs("class").Matching(highlightClassPattern).
+		OnElements("div", "pre", "code", "span", "table", "tr", "td")
+	return policy
+}()
+
+// highlightRenderer 代码块交给语法高亮处理，其余节点保持blackfriday的默认输出
+type highlightRenderer struct {
+	*blackfriday.HTMLRenderer
+}
+
+func (r *highlightRenderer) RenderNode(w io.Writer, node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+	if node.Type == blackfriday.CodeBlock {
+		if code, ok := highlightCode(string(node.Literal), string(node.Info)); ok {
+			_, _ = io.WriteString(w, code)
+			return blackfriday.GoToNext
+		}
+	}
+	return r.HTMLRenderer.RenderNode(w, node, entering)
+}
+
 func Markdown2Html(md string) string {
-	unsafe := blackfriday.Run(
-		[]byte(md),
-		blackfriday.WithExtensions(blackfriday.CommonExtensions|
-			blackfriday.HardLineBreak|
-			blackfriday.AutoHeadingIDs|
-			blackfriday.Autolink,
-		),
-	)
-	html := bluemonday.UGCPolicy().SanitizeBytes(unsafe)
-	return string(html)
+	html, _ := Markdown2HtmlWithToc(md)
+	return html
+}
+
+// Markdown2HtmlWithToc 将md转为过滤之后的html，同时返回由标题构成的目录，代码块带语法高亮
+// 目录中的id与html中标题的id一致，重复的标题会依次加上 -1、-2 后缀
+func Markdown2HtmlWithToc(md string) (string, []TocNode) {
+	renderer := &highlightRenderer{blackfriday.NewHTMLRenderer(blackfriday.HTMLRendererParameters{
+		Flags: blackfriday.CommonHTMLFlags,
+	})}
+	ast := blackfriday.New(blackfriday.WithExtensions(markdownExtensions)).Parse([]byte(md))
+	var headings []TocNode
+	usedIds := map[string]bool{}
+	ast.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if !entering || node.Type != blackfriday.Heading || node.IsTitleblock {
+			return blackfriday.GoToNext
+		}
+		// 提前确定唯一的id，渲染时就不会再被改写
+		id := node.HeadingID
+		if id == "" {
+			id = "heading"
+		}
+		if usedIds[id] {
+			base := id
+			for index := 1; usedIds[id]; index++ {
+				id = base + "-" + strconv.Itoa(index)
+			}
+		}
+		usedIds[id] = true
+		node.HeadingID = id
+		headings = append(headings, TocNode{Level: node.Level, Id: id, Text: headingText(node)})
+		return blackfriday.SkipChildren
+	})
+	var buffer bytes.Buffer
+	renderer.RenderHeader(&buffer, ast)
+	ast.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		return renderer.RenderNode(&buffer, node, entering)
+	})
+	renderer.RenderFooter(&buffer, ast)
+	return string(markdownPolicy.SanitizeBytes(buffer.Bytes())), buildToc(headings)
+}
+
+// headingText 标题的纯文本
+func headingText(heading *blackfriday.Node) string {
+	var buffer bytes.Buffer
+	heading.Walk(func(node *blackfriday.Node, entering bool) blackfriday.WalkStatus {
+		if entering && (node.Type == blackfriday.Text || node.Type == blackfriday.Code) {
+			buffer.Write(node.Literal)
+		}
+		return blackfriday.GoToNext
+	})
+	return buffer.String()
+}
+
+// buildToc 将平铺的标题按级别组织成树，跳级的标题挂在最近的更高级标题下
+func buildToc(headings []TocNode) []TocNode {
+	root := &TocNode{Level: 0, Children: []TocNode{}}
+	// stack 保存从根到当前位置的路径，元素是对应节点Children的下标
+	var stack []int
+	for _, heading := range headings {
+		heading.Children = []TocNode{}
+		for len(stack) > 0 && tocNodeAt(root, stack).Level >= heading.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := tocNodeAt(root, stack)
+		parent.Children = append(parent.Children, heading)
+		stack = append(stack, len(parent.Children)-1)
+	}
+	return root.Children
+}
+
+// tocNodeAt 沿下标路径找到节点
+func tocNodeAt(root *TocNode, path []int) *TocNode {
+	node := root
+	for _, index := range path {
+		node = &node.Children[index]
+	}
+	return node
 }
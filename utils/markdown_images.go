@@ -0,0 +1,29 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 提取md内容中引用的图片地址
+ * @File:  markdown_images
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import "regexp"
+
+// markdownImagePattern md的图片语法 ![alt](url "title")，url可以用尖括号包裹
+var markdownImagePattern = regexp.MustCompile(`!\[[^\]]*\]\(\s*<?([^)\s>]+)>?`)
+
+// htmlImagePattern 内嵌html的img标签
+var htmlImagePattern = regexp.MustCompile(`(?i)<img[^>]+src\s*=\s*["']([^"']+)["']`)
+
+// MarkdownImageUrls md内容中引用的所有图片地址，去重并保持出现的顺序
+func MarkdownImageUrls(md string) []string {
+	urls := make([]string, 0)
+	for _, pattern := range []*regexp.Regexp{markdownImagePattern, htmlImagePattern} {
+		for _, match := range pattern.FindAllStringSubmatch(md, -1) {
+			urls = appendUnique(urls, match[1])
+		}
+	}
+	return urls
+}
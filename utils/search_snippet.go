@@ -0,0 +1,197 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 搜索结果的摘要与高亮，搜索词的切分与建立md_words时一致
+ * @File:  search_snippet
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import (
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/russross/blackfriday/v2"
+	"html"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// SearchHighlightPre 高亮的开始标记
+	SearchHighlightPre = "<mark>"
+	// SearchHighlightPost 高亮的结束标记
+	SearchHighlightPost = "</mark>"
+	// searchSnippetRadius 摘要在命中位置前后保留的字数
+	searchSnippetRadius = 50
+	// searchSnippetEllipsis 摘要被截断时的省略号
+	searchSnippetEllipsis = "…"
+)
+
+// blockEndPattern 块级元素的结束标签，转为纯文本时在这里断开，避免前后两段粘在一起
+var blockEndPattern = regexp.MustCompile(`(?i)</(p|li|h[1-6]|pre|blockquote|tr|td|th|div)>|<br\s*/?>`)
+
+// MarkdownPlainText md内容对应的纯文本，连续的空白合并为一个空格
+func MarkdownPlainText(md string) string {
+	rendered := blackfriday.Run([]byte(md), blackfriday.WithExtensions(markdownExtensions))
+	rendered = blockEndPattern.ReplaceAll(rendered, []byte("$0 "))
+	text := html.UnescapeString(bluemonday.StrictPolicy().Sanitize(string(rendered)))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// SearchTokens 用与WordSplitForSearching相同的方式切分文本并转为小写，忽略空白与标点，重复的词会保留
+func SearchTokens(text string) []string {
+	tokens := make([]string, 0)
+	for _, word := range WordSplitSeg.CutSearch(text) {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" || strings.IndexFunc(word, func(r rune) bool {
+			return unicode.IsLetter(r) || unicode.IsDigit(r)
+		}) < 0 {
+			continue
+		}
+		tokens = append(tokens, word)
+	}
+	return tokens
+}
+
+// SearchTerms 搜索词切分之后去重，较长的词在前
+func SearchTerms(searchText string) []string {
+	terms := make([]string, 0)
+	seen := map[string]bool{}
+	for _, word := range SearchTokens(searchText) {
+		if !seen[word] {
+			seen[word] = true
+			terms = append(terms, word)
+		}
+	}
+	sort.SliceStable(terms, func(i, j int) bool {
+		return len([]rune(terms[i])) > len([]rune(terms[j]))
+	})
+	return terms
+}
+
+// HighlightText 转义文本并用高亮标记包裹所有命中的词
+func HighlightText(text string, terms []string) string {
+	runes := []rune(text)
+	return highlightRange(runes, searchMatches(runes, terms), 0, len(runes))
+}
+
+// HighlightSnippets 从文本中截取最多count段包含搜索词的摘要，命中的词用高亮标记包裹
+// 优先选择包含不同搜索词最多的片段，结果按在文本中的位置排列；没有命中时返回文本的开头
+func HighlightSnippets(text string, terms []string, count int) []string {
+	runes := []rune(text)
+	matches := searchMatches(runes, terms)
+	if len(matches) == 0 {
+		if len(runes) == 0 {
+			return []string{}
+		}
+		end := minInt(len(runes), 2*searchSnippetRadius)
+		return []string{highlightRange(runes, nil, 0, end)}
+	}
+	type window struct {
+		start, end int
+		matches    [][2]int
+		terms      int
+	}
+	windows := make([]window, 0)
+	for index := 0; index < len(matches); {
+		current := window{start: maxInt(0, matches[index][0]-searchSnippetRadius)}
+		limit := matches[index][1] + searchSnippetRadius
+		distinct := map[string]bool{}
+		for ; index < len(matches) && matches[index][1] <= limit; index++ {
+			current.matches = append(current.matches, matches[index])
+			distinct[strings.ToLower(string(runes[matches[index][0]:matches[index][1]]))] = true
+		}
+		current.end = minInt(len(runes), limit)
+		current.terms = len(distinct)
+		windows = append(windows, current)
+	}
+	sort.SliceStable(windows, func(i, j int) bool {
+		if windows[i].terms != windows[j].terms {
+			return windows[i].terms > windows[j].terms
+		}
+		return len(windows[i].matches) > len(windows[j].matches)
+	})
+	if len(windows) > count {
+		windows = windows[:count]
+	}
+	sort.Slice(windows, func(i, j int) bool { return windows[i].start < windows[j].start })
+	snippets := make([]string, 0, len(windows))
+	for _, val := range windows {
+		snippet := highlightRange(runes, val.matches, val.start, val.end)
+		if val.start > 0 {
+			snippet = searchSnippetEllipsis + snippet
+		}
+		if val.end < len(runes) {
+			snippet += searchSnippetEllipsis
+		}
+		snippets = append(snippets, snippet)
+	}
+	return snippets
+}
+
+// searchMatches 忽略大小写查找所有命中的位置，重叠的位置会被合并，结果按位置排序
+func searchMatches(runes []rune, terms []string) [][2]int {
+	lower := make([]rune, len(runes))
+	for index, r := range runes {
+		lower[index] = unicode.ToLower(r)
+	}
+	matches := make([][2]int, 0)
+	for _, term := range terms {
+		pattern := []rune(term)
+		if len(pattern) == 0 {
+			continue
+		}
+		for start := 0; start+len(pattern) <= len(lower); start++ {
+			if string(lower[start:start+len(pattern)]) == term {
+				matches = append(matches, [2]int{start, start + len(pattern)})
+				start += len(pattern) - 1
+			}
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i][0] < matches[j][0] })
+	merged := make([][2]int, 0, len(matches))
+	for _, val := range matches {
+		if last := len(merged) - 1; last >= 0 && val[0] <= merged[last][1] {
+			merged[last][1] = maxInt(merged[last][1], val[1])
+			continue
+		}
+		merged = append(merged, val)
+	}
+	return merged
+}
+
+// highlightRange 转义[start, end)范围内的文本并包裹其中的命中位置
+func highlightRange(runes []rune, matches [][2]int, start, end int) string {
+	var builder strings.Builder
+	cursor := start
+	for _, val := range matches {
+		if val[1] <= start || val[0] >= end {
+			continue
+		}
+		matchStart, matchEnd := maxInt(val[0], start), minInt(val[1], end)
+		builder.WriteString(html.EscapeString(string(runes[cursor:matchStart])))
+		builder.WriteString(SearchHighlightPre)
+		builder.WriteString(html.EscapeString(string(runes[matchStart:matchEnd])))
+		builder.WriteString(SearchHighlightPost)
+		cursor = matchEnd
+	}
+	builder.WriteString(html.EscapeString(string(runes[cursor:end])))
+	return builder.String()
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
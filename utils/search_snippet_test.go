@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHighlightText(t *testing.T) {
+	cases := []struct {
+		name  string
+		text  string
+		terms []string
+		want  string
+	}{
+		{
+			name: "ignore case",
+			text: "Go is fun", terms: []string{"go"},
+			want: "<mark>Go</mark> is fun",
+		},
+		{
+			name: "overlapping terms merge",
+			text: "golang", terms: []string{"golang", "go"},
+			want: "<mark>golang</mark>",
+		},
+		{
+			name: "escape html",
+			text: "a<go>", terms: []string{"go"},
+			want: "a&lt;<mark>go</mark>&gt;",
+		},
+		{
+			name: "adjacent terms merge",
+			text: "学习Go语言", terms: []string{"go", "语言"},
+			want: "学习<mark>Go语言</mark>",
+		},
+		{
+			name: "no match",
+			text: "hello", terms: []string{"go"},
+			want: "hello",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := HighlightText(c.text, c.terms); got != c.want {
+				t.Errorf("HighlightText() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestHighlightSnippets(t *testing.T) {
+	x48, x50, y45 := strings.Repeat("x", 48), strings.Repeat("x", 50), strings.Repeat("y", 45)
+	twoWindows := "go" + strings.Repeat("x", 200) + "go rust" + strings.Repeat("y", 200)
+	cases := []struct {
+		name  string
+		text  string
+		terms []string
+		count int
+		want  []string
+	}{
+		{
+			name: "empty text",
+			text: "", terms: []string{"go"}, count: 1,
+			want: []string{},
+		},
+		{
+			name: "no match returns the head",
+			text: strings.Repeat("x", 150), terms: []string{"go"}, count: 1,
+			want: []string{strings.Repeat("x", 100)},
+		},
+		{
+			name: "match in the middle",
+			text: strings.Repeat("a", 100) + "go" + strings.Repeat("b", 100), terms: []string{"go"}, count: 1,
+			want: []string{"…" + strings.Repeat("a", 50) + "<mark>go</mark>" + strings.Repeat("b", 50) + "…"},
+		},
+		{
+			name: "whole text within radius",
+			text: "go" + x48 + "go", terms: []string{"go"}, count: 1,
+			want: []string{"<mark>go</mark>" + x48 + "<mark>go</mark>"},
+		},
+		{
+			name: "match past radius starts a new snippet",
+			text: "go" + x50 + "go", terms: []string{"go"}, count: 2,
+			want: []string{"<mark>go</mark>" + x50 + "…", "…" + x50 + "<mark>go</mark>"},
+		},
+		{
+			name: "prefer window with more distinct terms",
+			text: twoWindows, terms: []string{"rust", "go"}, count: 1,
+			want: []string{"…" + x50 + "<mark>go</mark> <mark>rust</mark>" + y45 + "…"},
+		},
+		{
+			name: "snippets keep text order",
+			text: twoWindows, terms: []string{"rust", "go"}, count: 2,
+			want: []string{
+				"<mark>go</mark>" + x50 + "…",
+				"…" + x50 + "<mark>go</mark> <mark>rust</mark>" + y45 + "…",
+			},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := HighlightSnippets(c.text, c.terms, c.count); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("HighlightSnippets() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 由标题生成可读的slug，中文先用gse分词再转为不带声调的拼音
+ * 	FOLLOW: https://github.com/mozillazg/go-pinyin
+ * @File:  slug
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import (
+	"github.com/mozillazg/go-pinyin"
+	"strings"
+	"unicode"
+)
+
+// slugMaxLength slug的最大长度，超出时在单词边界截断
+const slugMaxLength = 80
+
+var pinyinArgs = pinyin.NewArgs()
+
+// Slugify 将文本转为由小写字母、数字与"-"组成的slug
+// 中文词语内的拼音连写，词语之间以"-"分隔，如 "Go语言 入门" -> "go-yuyan-rumen"
+// 无法转写的字符被当作分隔符，全部无法转写时返回空串
+func Slugify(text string) string {
+	var tokens []string
+	for _, segment := range WordSplitSeg.Cut(text, true) {
+		tokens = append(tokens, slugTokens(segment)...)
+	}
+	var builder strings.Builder
+	for _, token := range tokens {
+		if builder.Len() > 0 && builder.Len()+1+len(token) > slugMaxLength {
+			break
+		}
+		if builder.Len() > 0 {
+			builder.WriteByte('-')
+		}
+		if len(token) > slugMaxLength {
+			token = token[:slugMaxLength]
+		}
+		builder.WriteString(token)
+	}
+	return builder.String()
+}
+
+// slugTokens 一个分词结果中的英文数字串与汉字串分别成为一个token
+func slugTokens(segment string) []string {
+	var tokens []string
+	var current strings.Builder
+	han := false
+	flush := func() {
+		if current.Len() > 0 {
+			tokens = append(tokens, current.String())
+			current.Reset()
+		}
+	}
+	for _, r := range segment {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if han {
+				flush()
+				han = false
+			}
+			current.WriteRune(unicode.ToLower(r))
+		case unicode.Is(unicode.Han, r):
+			if !han {
+				flush()
+				han = true
+			}
+			if syllables := pinyin.LazyPinyin(string(r), pinyinArgs); len(syllables) > 0 {
+				current.WriteString(syllables[0])
+			}
+		default:
+			flush()
+		}
+	}
+	flush()
+	return tokens
+}
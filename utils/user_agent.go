@@ -0,0 +1,76 @@
+// Package utils
+/**
+ * @Author: r0
+ * @Mail: boogieLing_o@qq.com
+ * @Description: 粗略地从UA中识别设备类型与浏览器，只用于统计
+ * @File:  user_agent
+ * @Version: 1.0.0
+ * @Date: 2026/10/18
+ */
+package utils
+
+import "strings"
+
+// 设备类型
+const (
+	DeviceDesktop = "desktop"
+	DeviceMobile  = "mobile"
+	DeviceTablet  = "tablet"
+	DeviceBot     = "bot"
+	DeviceUnknown = "unknown"
+)
+
+// botKeywords 爬虫的UA中常见的关键字
+var botKeywords = []string{"bot", "spider", "crawl", "slurp", "curl/", "wget/", "python-requests", "headless"}
+
+// browserKeywords 浏览器与UA中的关键字，按顺序匹配，基于Chromium的浏览器需要排在Chrome之前
+var browserKeywords = []struct {
+	name     string
+	keywords []string
+}{
+	{"WeChat", []string{"micromessenger"}},
+	{"QQ", []string{"qqbrowser", " qq/"}},
+	{"Edge", []string{"edg/", "edge/", "edga/", "edgios/"}},
+	{"Opera", []string{"opr/", "opera"}},
+	{"Samsung", []string{"samsungbrowser"}},
+	{"Chrome", []string{"chrome/", "crios/", "chromium/"}},
+	{"Firefox", []string{"firefox/", "fxios/"}},
+	{"Safari", []string{"safari/"}},
+	{"IE", []string{"msie ", "trident/"}},
+}
+
+// ParseUserAgent 从UA中识别设备类型与浏览器，无法识别的浏览器为Other
+func ParseUserAgent(userAgent string) (device string, browser string) {
+	ua := strings.ToLower(userAgent)
+	if strings.TrimSpace(ua) == "" {
+		return DeviceUnknown, "Other"
+	}
+	browser = "Other"
+	for _, val := range browserKeywords {
+		if containsAny(ua, val.keywords) {
+			browser = val.name
+			break
+		}
+	}
+	switch {
+	case containsAny(ua, botKeywords):
+		device = DeviceBot
+	case containsAny(ua, []string{"ipad", "tablet"}) ||
+		(strings.Contains(ua, "android") && !strings.Contains(ua, "mobile")):
+		device = DeviceTablet
+	case containsAny(ua, []string{"mobi", "iphone", "ipod", "android", "windows phone"}):
+		device = DeviceMobile
+	default:
+		device = DeviceDesktop
+	}
+	return device, browser
+}
+
+func containsAny(text string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package utils
+
+import "testing"
+
+func TestParseUserAgent(t *testing.T) {
+	cases := []struct {
+		name      string
+		userAgent string
+		device    string
+		browser   string
+	}{
+		{
+			name:      "empty",
+			userAgent: " ",
+			device:    DeviceUnknown, browser: "Other",
+		},
+		{
+			name:      "chrome on windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36",
+			device:    DeviceDesktop, browser: "Chrome",
+		},
+		{
+			name:      "edge before chrome",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36 Edg/118.0.2088.46",
+			device:    DeviceDesktop, browser: "Edge",
+		},
+		{
+			name:      "firefox on linux",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:109.0) Gecko/20100101 Firefox/118.0",
+			device:    DeviceDesktop, browser: "Firefox",
+		},
+		{
+			name:      "safari on iphone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			device:    DeviceMobile, browser: "Safari",
+		},
+		{
+			name:      "chrome on ios",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/118.0.5993.92 Mobile/15E148 Safari/604.1",
+			device:    DeviceMobile, browser: "Chrome",
+		},
+		{
+			name:      "wechat on android phone",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; Pixel 7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/116.0.0.0 Mobile Safari/537.36 MicroMessenger/8.0.40",
+			device:    DeviceMobile, browser: "WeChat",
+		},
+		{
+			name:      "android tablet",
+			userAgent: "Mozilla/5.0 (Linux; Android 13; SM-X700) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/118.0.0.0 Safari/537.36",
+			device:    DeviceTablet, browser: "Chrome",
+		},
+		{
+			name:      "ipad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 16_6 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/16.6 Mobile/15E148 Safari/604.1",
+			device:    DeviceTablet, browser: "Safari",
+		},
+		{
+			name:      "googlebot",
+			userAgent: "Mozilla/5.0 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			device:    DeviceBot, browser: "Other",
+		},
+		{
+			name:      "curl",
+			userAgent: "curl/8.1.2",
+			device:    DeviceBot, browser: "Other",
+		},
+		{
+			name:      "internet explorer",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; WOW64; Trident/7.0; rv:11.0) like Gecko",
+			device:    DeviceDesktop, browser: "IE",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			device, browser := ParseUserAgent(c.userAgent)
+			if device != c.device || browser != c.browser {
+				t.Errorf("ParseUserAgent() = %s, %s, want %s, %s", device, browser, c.device, c.browser)
+			}
+		})
+	}
+}
@@ -12,6 +12,7 @@ package utils
 
 import (
 	"bytes"
+	"math"
 	"mvdan.cc/xurls/v2"
 	"regexp"
 	"strings"
@@ -21,18 +22,39 @@ import (
 )
 
 type WordCounter struct {
-	Total     int // 总字数 = Words + Puncts
-	Words     int // 只包含字符数
-	Puncts    int // 标点数
-	Links     int // 链接数
-	Pics      int // 图片数
-	CodeLines int // 代码行数
+	Total      int // 总字数 = Words + Puncts
+	Words      int // 只包含字符数
+	Puncts     int // 标点数
+	Links      int // 链接数
+	Pics       int // 图片数
+	CodeLines  int // 代码行数
+	CJKChars   int // 代码块以外的中日韩文字数
+	LatinWords int // 代码块以外拉丁文等以空格分词的单词数
 }
 
+// 估算阅读时长使用的阅读速度
+const (
+	cjkCharsPerMinute   = 400 // 每分钟阅读的中日韩文字数
+	latinWordsPerMinute = 230 // 每分钟阅读的英文单词数
+	codeLinesPerMinute  = 40  // 每分钟阅读的代码行数
+)
+
+// Stat 统计md内容，总字数包含代码块中的内容
+// 中日韩文字数与单词数只统计代码块以外的内容，代码块单独按行数计入阅读时长
 func (wc *WordCounter) Stat(str string) {
 	wc.Links = len(rxStrict.FindAllString(str, -1))
-	wc.Pics = len(imgReg.FindAllString(str, -1))
+	wc.Pics = len(imgReg.FindAllString(str, -1)) + len(mdImgReg.FindAllString(str, -1))
+
+	wc.Words, wc.Puncts, _, _ = countWords(str)
+	wc.Total = wc.Words + wc.Puncts
+
+	prose, codeLines := stripCodeBlocks(str)
+	wc.CodeLines = codeLines
+	_, _, wc.CJKChars, wc.LatinWords = countWords(prose)
+}
 
+// countWords 统计字数、标点数、中日韩文字数与以空格分词的单词数
+func countWords(str string) (words, puncts, cjkChars, latinWords int) {
 	// 剔除 HTML
 	str = StripHTML(str)
 
@@ -43,25 +65,78 @@ func (wc *WordCounter) Stat(str string) {
 	plainWords := strings.Fields(str)
 
 	for _, plainWord := range plainWords {
-		words := strings.FieldsFunc(plainWord, func(r rune) bool {
+		fields := strings.FieldsFunc(plainWord, func(r rune) bool {
 			if unicode.IsPunct(r) {
-				wc.Puncts++
+				puncts++
 				return true
 			}
 			return false
 		})
 
-		for _, word := range words {
+		for _, word := range fields {
 			runeCount := utf8.RuneCountInString(word)
 			if len(word) == runeCount {
-				wc.Words++
+				words++
+				latinWords++
 			} else {
-				wc.Words += runeCount
+				words += runeCount
+				cjk := 0
+				for _, r := range word {
+					if isCJK(r) {
+						cjk++
+					}
+				}
+				cjkChars += cjk
+				if cjk < runeCount {
+					latinWords++
+				}
 			}
 		}
 	}
+	return words, puncts, cjkChars, latinWords
+}
 
-	wc.Total = wc.Words + wc.Puncts
+// ReadingMinutes 估算的阅读分钟数，向上取整，有内容时至少为1
+func (wc *WordCounter) ReadingMinutes() int {
+	minutes := float64(wc.CJKChars)/cjkCharsPerMinute +
+		float64(wc.LatinWords)/latinWordsPerMinute +
+		float64(wc.CodeLines)/codeLinesPerMinute
+	return int(math.Ceil(minutes))
+}
+
+// stripCodeBlocks 去掉```或~~~包裹的代码块，返回剩余的内容与代码块中非空的行数
+func stripCodeBlocks(str string) (string, int) {
+	if !strings.Contains(str, "```") && !strings.Contains(str, "~~~") {
+		return str, 0
+	}
+	b := GetBuffer()
+	defer PutBuffer(b)
+	fence, codeLines := "", 0
+	for _, line := range strings.SplitAfter(str, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if fence == "" {
+			if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+				fence = trimmed[:3]
+				continue
+			}
+			b.WriteString(line)
+			continue
+		}
+		// 结束的标记只能由标记字符组成
+		if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			fence = ""
+			continue
+		}
+		if trimmed != "" {
+			codeLines++
+		}
+	}
+	return b.String(), codeLines
+}
+
+// isCJK 是否为中日韩文字
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
 }
 
 // AutoSpace 自动给中英文之间加上空格
@@ -92,6 +167,7 @@ func addSpaceAtBoundary(prefix string, nextChar rune) string {
 var (
 	rxStrict          = xurls.Strict()
 	imgReg            = regexp.MustCompile(`<img [^>]*>`)
+	mdImgReg          = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
 	stripHTMLReplacer = strings.NewReplacer("\n", " ", "</p>", "\n", "<br>", "\n", "<br />", "\n")
 )
 
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWordCounterStat(t *testing.T) {
+	cases := []struct {
+		name string
+		text string
+		want WordCounter
+	}{
+		{
+			name: "cjk only",
+			text: "你好，世界。",
+			want: WordCounter{Total: 6, Words: 4, Puncts: 2, CJKChars: 4},
+		},
+		{
+			name: "latin only",
+			text: "Hello world, go!",
+			want: WordCounter{Total: 5, Words: 3, Puncts: 2, LatinWords: 3},
+		},
+		{
+			name: "mixed",
+			text: "学习Go语言",
+			want: WordCounter{Total: 5, Words: 5, CJKChars: 4, LatinWords: 1},
+		},
+		{
+			name: "code block counts in total only",
+			text: "intro\n```go\nfmt.Println(1)\n\nreturn\n```\nend\n",
+			want: WordCounter{Total: 11, Words: 8, Puncts: 3, CodeLines: 2, LatinWords: 2},
+		},
+		{
+			name: "tilde fence",
+			text: "a\n~~~\nx\ny\n~~~\nb\n",
+			want: WordCounter{Total: 6, Words: 6, CodeLines: 2, LatinWords: 2},
+		},
+		{
+			name: "unclosed fence runs to the end",
+			text: "文字\n```\ncode\nmore\n",
+			want: WordCounter{Total: 5, Words: 5, CodeLines: 2, CJKChars: 2},
+		},
+		{
+			name: "links and pictures",
+			text: "see https://example.com ![pic](a.png) <img src=\"b.png\">",
+			want: WordCounter{Total: 10, Words: 4, Puncts: 6, Links: 1, Pics: 2, LatinWords: 4},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var got WordCounter
+			got.Stat(c.text)
+			if got != c.want {
+				t.Errorf("Stat() = %+v, want %+v", got, c.want)
+			}
+		})
+	}
+}
+
+func TestWordCounterReadingMinutes(t *testing.T) {
+	cases := []struct {
+		name    string
+		counter WordCounter
+		want    int
+	}{
+		{name: "empty", counter: WordCounter{}, want: 0},
+		{name: "short cjk", counter: WordCounter{CJKChars: 10}, want: 1},
+		{name: "exact cjk minute", counter: WordCounter{CJKChars: 400}, want: 1},
+		{name: "cjk rounds up", counter: WordCounter{CJKChars: 401}, want: 2},
+		{name: "latin", counter: WordCounter{LatinWords: 460}, want: 2},
+		{name: "mixed with code", counter: WordCounter{CJKChars: 400, LatinWords: 230, CodeLines: 40}, want: 3},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.counter.ReadingMinutes(); got != c.want {
+				t.Errorf("ReadingMinutes() = %d, want %d", got, c.want)
+			}
+		})
+	}
+	var counter WordCounter
+	counter.Stat(strings.Repeat("字", 800))
+	if got := counter.ReadingMinutes(); got != 2 {
+		t.Errorf("ReadingMinutes() after Stat = %d, want 2", got)
+	}
+}